@@ -6,7 +6,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
-	"time"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -14,15 +14,15 @@ import (
 
 func archiveBuilds(outputDir string) error {
 
-	runs, err := GetWorkflowRunsOfBranch("apache", "hadoop-ozone", "8247", "master")
+	runs, err := GetWorkflowRunsOfBranch(currentConfig.Org, currentConfig.Repo, currentConfig.Workflows.Build, currentConfig.Branch)
 	if err != nil {
 		return err
 	}
 
 	limit := 50
-	for _, run := range l(m(runs, "workflow_runs")) {
+	for _, run := range runs.WorkflowRuns {
 
-		if (ms(run,"event") == "pull_request") {
+		if run.Event == "pull_request" {
 			continue
 		}
 
@@ -30,14 +30,8 @@ func archiveBuilds(outputDir string) error {
 		if limit == 0 {
 			break
 		}
-		createdString := ms(run, "created_at")
-		created, err := time.Parse(time.RFC3339, createdString)
-		if err != nil {
-			return errors.Wrap(err, "Can't parse creation time of the build "+createdString)
-		}
-
-		runId := mns(run, "run_number")
-		buildDir := path.Join(outputDir, created.Format("2006/01/02"), runId)
+		runId := strconv.Itoa(run.RunNumber)
+		buildDir := path.Join(outputDir, run.CreatedAt.Format("2006/01/02"), runId)
 		log.Info().Msgf("Download artifacts of build %s", runId)
 
 		runJson := path.Join(buildDir, "run.json")
@@ -56,23 +50,22 @@ func archiveBuilds(outputDir string) error {
 		//jobs were finished
 		if _, err := os.Stat(jobJson); os.IsNotExist(err) {
 		} else {
-			jobContent, err := asJson(ioutil.ReadFile(jobJson))
+			jobContent, err := ioutil.ReadFile(jobJson)
 			if err != nil {
 				return err
 			}
-			allDone := true
-			for _, job := range l(m(jobContent, "jobs")) {
-				if ms(job, "status") != "completed" {
-					allDone = false
-				}
+			jobs := Jobs{}
+			err = json.Unmarshal(jobContent, &jobs)
+			if err != nil {
+				return errors.Wrap(err, "Can't parse job file "+jobJson)
 			}
-			if allDone {
+			if jobs.Completed() {
 				continue
 			}
 			log.Print(runId + " is already downloaded but it was in-progress")
 		}
 		_ = os.MkdirAll(buildDir, 0755)
-		err = downloadArtifactsOfRun("apache", mns(run, "id"), buildDir, false)
+		err = downloadArtifactsOfRun(currentConfig.Org, currentConfig.Repo, run.IdString(), buildDir, artifactFilter{})
 		if err != nil {
 			return errors.Wrap(err, "Can't download artifact of the build "+runId)
 		}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"path"
+	"strings"
+)
+
+//selection of the artifacts to download
+type artifactFilter struct {
+	//download the artifacts of the successful jobs, too
+	All bool
+	//glob patterns of the artifact names to download (all artifacts if empty). Artifacts without known job are downloaded only if they are included.
+	Include []string
+	//glob patterns of the artifact names to skip
+	Exclude []string
+	//job groups (basic, integration, acceptance, other) to download (all groups if empty)
+	Groups []string
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+//true if the artifact should be downloaded. job is the job which created the artifact (nil if unknown)
+func (filter artifactFilter) selected(name string, job *Job) bool {
+	if matchesAny(filter.Exclude, name) {
+		return false
+	}
+	if len(filter.Include) > 0 && !matchesAny(filter.Include, name) {
+		return false
+	}
+	if job == nil {
+		//the result of the job is unknown, only the explicitly included artifacts are downloaded
+		return len(filter.Include) > 0
+	}
+	if len(filter.Groups) > 0 && !containsString(filter.Groups, jobGroups[jobGroupIndex(job.Name)]) {
+		return false
+	}
+	return filter.All || job.Conclusion == "failure"
+}
+
+func (filter artifactFilter) validate() error {
+	for _, pattern := range append(append([]string{}, filter.Include...), filter.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.New("Invalid artifact name pattern: " + pattern)
+		}
+	}
+	for _, group := range filter.Groups {
+		if !containsString(jobGroups, group) {
+			return errors.New("Unknown job group: " + group + " (use " + strings.Join(jobGroups, ", ") + ")")
+		}
+	}
+	return nil
+}
+
+func artifactFilterFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:  "all",
+			Usage: "If not used, only the failed artifacts will be downloaded.",
+		},
+		cli.StringSliceFlag{
+			Name:  "include",
+			Usage: "Download only the artifacts with matching name (glob pattern like it-*, can be repeated)",
+		},
+		cli.StringSliceFlag{
+			Name:  "exclude",
+			Usage: "Skip the artifacts with matching name (glob pattern, can be repeated)",
+		},
+		cli.StringSliceFlag{
+			Name:  "group",
+			Usage: "Download only the artifacts of the job group (basic, integration, acceptance, other; can be repeated)",
+		},
+	}
+}
+
+func artifactFilterFromContext(c *cli.Context) (artifactFilter, error) {
+	filter := artifactFilter{
+		All:     c.Bool("all"),
+		Include: c.StringSlice("include"),
+		Exclude: c.StringSlice("exclude"),
+		Groups:  c.StringSlice("group"),
+	}
+	return filter, filter.validate()
+}
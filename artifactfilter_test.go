@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArtifactFilter(t *testing.T) {
+	failedIt := &Job{Name: "integration (ozone)", Conclusion: "failure"}
+	passedIt := &Job{Name: "integration (hdds)", Conclusion: "success"}
+	failedAcceptance := &Job{Name: "acceptance (secure)", Conclusion: "failure"}
+
+	//failed only by default
+	filter := artifactFilter{}
+	assert.True(t, filter.selected("it-ozone", failedIt))
+	assert.False(t, filter.selected("it-hdds", passedIt))
+	assert.False(t, filter.selected("coverage", nil))
+
+	filter = artifactFilter{All: true, Groups: []string{"integration"}}
+	assert.True(t, filter.selected("it-ozone", failedIt))
+	assert.True(t, filter.selected("it-hdds", passedIt))
+	assert.False(t, filter.selected("acceptance-secure", failedAcceptance))
+	assert.False(t, filter.selected("coverage", nil))
+
+	filter = artifactFilter{All: true, Include: []string{"it-*", "acceptance-*"}, Exclude: []string{"it-hdds"}}
+	assert.True(t, filter.selected("it-ozone", failedIt))
+	assert.False(t, filter.selected("it-hdds", passedIt))
+	assert.True(t, filter.selected("acceptance-secure", failedAcceptance))
+	assert.False(t, filter.selected("coverage", nil))
+
+	//artifacts without known job are downloaded only if they are included
+	assert.False(t, artifactFilter{All: true}.selected("coverage", nil))
+	assert.True(t, artifactFilter{Include: []string{"coverage"}}.selected("coverage", nil))
+	assert.True(t, artifactFilter{All: true, Include: []string{"cov*"}}.selected("coverage", nil))
+}
+
+func TestArtifactFilterValidate(t *testing.T) {
+	assert.Nil(t, artifactFilter{Include: []string{"it-*"}, Groups: []string{"Acceptance"}}.validate())
+	assert.NotNil(t, artifactFilter{Include: []string{"it-["}}.validate())
+	assert.NotNil(t, artifactFilter{Groups: []string{"unit"}}.validate())
+}
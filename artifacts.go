@@ -8,68 +8,81 @@ import (
 	"os"
 	"path"
 	"path/filepath"
-	"strings"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 )
 
-func downloadArtifacts(org string, buildIdExpression string, destinationDir string, all bool) error {
-
-	if strings.HasPrefix(buildIdExpression, "pr/") {
-		pr, err := GetPr(org, "ozone", buildIdExpression[3:])
+//download the artifacts of a run defined by the reference (pull request, run id/number or the last run of a branch)
+func downloadArtifacts(ref Reference, destinationDir string, filter artifactFilter) error {
+	org := ref.Org
+	repo := ref.Repo
+	if ref.Kind == referencePr {
+		pr, err := GetPr(org, repo, ref.Id)
 		if err != nil {
 			return err
 		}
-		branch := ms(pr, "head", "ref")
+		branch := pr.Head.Ref
 
-		workflowRuns, err := GetWorkflowRunsOfBranch(org, "ozone", "8247", branch)
+		workflowRuns, err := GetWorkflowRunsOfBranch(org, repo, currentConfig.Workflows.Build, branch)
 		if err != nil {
 			return err
 		}
-		id := mns(l(m(workflowRuns, "workflow_runs"))[0], "id")
-		return downloadArtifactsOfRun(org, id, destinationDir+"/"+buildIdExpression, false)
-	} else if strings.HasPrefix(buildIdExpression, "#") {
-		return downloadArtifactsOfRun(org, buildIdExpression[1:], destinationDir+"/"+buildIdExpression[1:], all)
+		if len(workflowRuns.WorkflowRuns) == 0 {
+			return errors.New("No workflow run is found for the branch " + branch)
+		}
+		id := workflowRuns.WorkflowRuns[0].IdString()
+		return downloadArtifactsOfRun(org, repo, id, path.Join(destinationDir, "pr", ref.Id), filter)
+	} else if ref.Kind == referenceRun {
+		return downloadArtifactsOfRun(org, repo, ref.Id, path.Join(destinationDir, ref.Id), filter)
+	} else if ref.Id == "" {
+		workflowRuns, err := GetWorkflowRunsOfBranch(org, repo, currentConfig.Workflows.Build, ref.Branch)
+		if err != nil {
+			return err
+		}
+		if len(workflowRuns.WorkflowRuns) == 0 {
+			return errors.New("No workflow run is found for the branch " + ref.Branch)
+		}
+		id := workflowRuns.WorkflowRuns[0].IdString()
+		return downloadArtifactsOfRun(org, repo, id, path.Join(destinationDir, id), filter)
 	} else {
-		workflowRuns, err := GetAllWorkflowRuns(org, "hadoop-ozone")
+		workflowRuns, err := GetAllWorkflowRuns(org, repo)
 
 		if err == nil {
-			for _, run := range l(m(workflowRuns, "workflow_runs")) {
-				runId := mns(run, "id")
-				if mns(run, "run_number") == buildIdExpression {
-					return downloadArtifactsOfRun(org, runId, destinationDir+"/"+runId, all)
+			for _, run := range workflowRuns.WorkflowRuns {
+				runId := run.IdString()
+				if strconv.Itoa(run.RunNumber) == ref.Id {
+					return downloadArtifactsOfRun(org, repo, runId, path.Join(destinationDir, runId), filter)
 				}
 
-				if buildIdExpression == runId {
-					return downloadArtifactsOfRun(org, runId, destinationDir+"/"+runId, all)
+				if ref.Id == runId {
+					return downloadArtifactsOfRun(org, repo, runId, path.Join(destinationDir, runId), filter)
 				}
 			}
 		}
 
 	}
-	return errors.New("Build is not found: " + buildIdExpression + " use pr/NUM or #NUM " +
-		"(where NUM is the number from the url of https://github.com/elek/apache/actions/runs/527828208)" +
+	return errors.New("Build is not found: " + ref.Id + " use pr/NUM, run/NUM " +
+		"(where NUM is the number from the url of https://github.com/apache/ozone/actions/runs/527828208)" +
+		", a github url, org/repo@branch for the last build of a branch" +
 		" or just NUM where NUM is the index of the build")
 }
 
-func downloadArtifactsOfRun(org string, runId string, destinationDir string, all bool) error {
+func downloadArtifactsOfRun(org string, repo string, runId string, destinationDir string, filter artifactFilter) error {
 
-	apiGetter := func() ([]byte, error) {
-		return readGithubApiV3("https://api.github.com/repos/" + org + "/hadoop-ozone/actions/runs/" + runId + "/artifacts")
-	}
-	artifacts, err := asJson(cachedGet3min(apiGetter, org+"-actions-runs-"+runId+"-artifacts"))
+	artifacts, err := GetArtifacts(org, repo, runId)
 	if err != nil {
 		return err
 	}
 
-	results := make(map[string]interface{})
-	jobs, err := GetWorkflowRunJobs(org, "hadoop-ozone", runId)
+	artifactJobs := make(map[string]Job)
+	jobs, err := GetWorkflowRunJobs(org, repo, runId)
 	if err != nil {
 		return err
 	}
-	for _, job := range l(m(jobs, "jobs")) {
-		results[JobToArtifactName(ms(job, "name"))] = ms(job, "conclusion")
+	for _, job := range jobs.Jobs {
+		artifactJobs[JobToArtifactName(job.Name)] = job
 	}
 
 	err = os.MkdirAll(destinationDir, 0755)
@@ -87,43 +100,46 @@ func downloadArtifactsOfRun(org string, runId string, destinationDir string, all
 		return errors.Wrap(err, "Can't write out job file to "+jsonJobFile)
 	}
 
-	for _, artifact := range l(m(artifacts, "artifacts")) {
-		name := ms(artifact, "name")
-		result, found := results[name]
-		if !found {
+	downloads := make([]artifactDownload, 0)
+	for _, artifact := range artifacts.Artifacts {
+		name := artifact.Name
+		var job *Job
+		if artifactJob, found := artifactJobs[name]; found {
+			job = &artifactJob
+		} else {
 			log.Debug().Msg("Job result for the artifact " + name + " is unknown")
-		} else if all || result == "failure" {
-
-			log.Info().Msg("Downloading results of " + name + " to " + destinationDir)
-			err = downloadAndExtract(name, ms(artifact, "archive_download_url"), destinationDir)
-			if err != nil {
-				return err
-			}
 		}
-	}
-	return nil
-}
-
-func downloadAndExtract(name string, url string, destinationDir string) error {
-
-	zipPath := path.Join(destinationDir, name+".zip")
-
-	if _, err := os.Stat(zipPath); os.IsNotExist(err) {
-		resp, err := callGithubApiV3("GET", url)
-		if err != nil {
-			return err
+		if filter.selected(name, job) {
+			downloads = append(downloads, artifactDownload{
+				Name: name,
+				Url:  artifact.ArchiveDownloadUrl,
+				Size: artifact.SizeInBytes,
+				Path: path.Join(destinationDir, name+".zip"),
+			})
 		}
-		defer resp.Body.Close()
+	}
+	if len(downloads) == 0 {
+		log.Info().Msg("None of the artifacts are selected to download")
+		return nil
+	}
 
-		zipFile, err := os.Create(zipPath)
-		if err != nil {
-			return err
+	log.Info().Msgf("Downloading %d artifacts to %s", len(downloads), destinationDir)
+	downloadErr := downloadAll(downloads, parallelDownloads, newDownloadProgress(os.Stderr))
+	//the successfully downloaded artifacts are extracted even if some of the downloads are failed
+	for _, download := range downloads {
+		if _, err := os.Stat(download.Path); err != nil {
+			continue
 		}
-		_, err = io.Copy(zipFile, resp.Body)
+		err = extractArtifact(download.Name, download.Path, destinationDir)
 		if err != nil {
 			return err
 		}
 	}
+	return downloadErr
+}
+
+//extract the downloaded zip file to the directory of the artifact and remove the zip file
+func extractArtifact(name string, zipPath string, destinationDir string) error {
 	r, err := zip.OpenReader(zipPath)
 	if err != nil {
 		return err
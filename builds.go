@@ -1,173 +1,236 @@
 package main
 
 import (
-	js "github.com/elek/go-utils/json"
 	"github.com/olekukonko/tablewriter"
+	"io"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
-func listForkBuilds(user string) error {
-	apiUrl := "https://api.github.com/repos/" + user + "/ozone/actions/workflows/134817/runs?event=push"
-	cacheKey := user + "-ozone-actions-workflows-134817-runs-push"
-	apiGetter := func() ([]byte, error) {
-		return readGithubApiV3(apiUrl)
+//options of the build listing commands
+type buildListOptions struct {
+	Format string
+	//poll and redraw the table until the watched runs are finished
+	Watch    bool
+	Interval time.Duration
+}
+
+//last push builds of the branches of a fork
+func readForkBuilds(user string) ([]WorkflowRun, error) {
+	repo := currentConfig.ForkRepo
+	workflow, err := resolveWorkflowId(user, repo, currentConfig.Workflows.Fork)
+	if err != nil {
+		return nil, err
 	}
-	runs, err := js.AsJson(cachedGet3min(apiGetter, cacheKey))
+	runsUrl := apiUrl("/repos/") + user + "/" + repo + "/actions/workflows/" + workflow + "/runs?event=push"
+	cacheKey := repoCacheKey(user, repo, "actions-workflows-"+workflow+"-runs-push")
+	apiGetter := restGetter(runsUrl)
+	runs := WorkflowRuns{}
+	err = cachedJson(apiGetter, cacheKey, timeCache3min, &runs)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	handled := make(map[string]bool)
-	lastRuns := make([]interface{}, 0)
+	lastRuns := make([]WorkflowRun, 0)
 	//only keep the first occurrences
-	for _, run := range js.L(js.M(runs, "workflow_runs")) {
-		key := js.MS(run, "head_branch")
+	for _, run := range runs.WorkflowRuns {
+		key := run.HeadBranch
 		if _, found := handled[key]; !found {
 			handled[key] = true
 			lastRuns = append(lastRuns, run)
 
 		}
 	}
+	return lastRuns, nil
+}
 
-
-	err = printWorkflowRuns(user, lastRuns)
-	if err != nil {
-		return err
+func listForkBuilds(user string, options buildListOptions) error {
+	read := func() ([]WorkflowRun, error) {
+		return readForkBuilds(user)
 	}
-	return nil
+	return listWorkflowRuns(user, currentConfig.ForkRepo, read, options)
 }
 
-func listBuilds(org string, branch string, workflowId int) error {
-	fork := org
+func readBuilds(ref Reference, workflow string) ([]WorkflowRun, error) {
+	org := ref.Org
+	repo := ref.Repo
+	branch := ref.Branch
 
-	cacheKey := "runs-" + org + "-"
+	cacheKey := "runs"
 
-	apiUrl := "https://api.github.com/repos/" + fork + "/hadoop-ozone/actions/"
-	if workflowId > 0 {
-		cacheKey += "-" + strconv.Itoa(workflowId)
-		apiUrl += "workflows/" + strconv.Itoa(workflowId) + "/"
+	workflowId := ""
+	if workflow != "" {
+		var err error
+		workflowId, err = resolveWorkflowId(org, repo, workflow)
+		if err != nil {
+			return nil, err
+		}
 	}
-	apiUrl += "runs?per_page=50"
+
+	runsUrl := apiUrl("/repos/") + org + "/" + repo + "/actions/"
+	if workflowId != "" {
+		cacheKey += "-" + workflowId
+		runsUrl += "workflows/" + workflowId + "/"
+	}
+	runsUrl += "runs?per_page=50"
 	if branch != "" {
 		cacheKey += "-" + branch
-		apiUrl = apiUrl + "&branch=" + branch
-	}
-	apiGetter := func() ([]byte, error) {
-		return readGithubApiV3(apiUrl)
+		runsUrl = runsUrl + "&branch=" + branch
 	}
-	runs, err := asJson(cachedGet3min(apiGetter, cacheKey))
+	apiGetter := restGetter(runsUrl)
+	runs := WorkflowRuns{}
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, cacheKey), timeCache3min, &runs)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	return runs.WorkflowRuns, nil
+}
 
-	workflowRuns := js.L(js.M(runs, "workflow_runs"))
-	err2 := printWorkflowRuns(org, workflowRuns)
-	if err2 != nil {
-		return err2
+func listBuilds(ref Reference, workflow string, options buildListOptions) error {
+	read := func() ([]WorkflowRun, error) {
+		return readBuilds(ref, workflow)
 	}
+	return listWorkflowRuns(ref.Org, ref.Repo, read, options)
+}
 
-	return err
+func listWorkflowRuns(org string, repo string, read func() ([]WorkflowRun, error), options buildListOptions) error {
+	if options.Watch {
+		return watchWorkflowRuns(org, repo, read, options.Interval)
+	}
+	runs, err := read()
+	if err != nil {
+		return err
+	}
+	return printWorkflowRuns(org, repo, runs, options.Format)
 }
 
-func printWorkflowRuns(org string, runs []interface{}) error {
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"#run", "id", "created", "workflow", "branch", "commit", "Checks"})
-	table.SetAutoWrapText(false)
-	println()
+//workflow run with the details which are displayed
+type runDetails struct {
+	Run      WorkflowRun
+	Workflow Workflow
+	Jobs     []Job
+}
 
+func readRunDetails(org string, repo string, runs []WorkflowRun) ([]runDetails, error) {
+	result := make([]runDetails, 0)
 	for _, run := range runs {
-
-		jobsUrl := ms(run, "jobs_url")
-		jobs, err := asJson(cachedGet(func() ([]byte, error) {
-			return readGithubApiV3(jobsUrl)
-		},
-			org+"-"+"hadoop-ozone"+"-"+"-actions-runs-"+part(jobsUrl, -2)+"-jobs",
-			buildResultCache))
+		jobs, err := GetWorkflowRunJobs(org, repo, run.IdString())
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		workflowUrl := ms(run, "workflow_url")
-		workflow, err := asJson(cachedGet3min(func() ([]byte, error) {
-			return readGithubApiV3(workflowUrl)
-		}, org+"-"+"hadoop-ozone-"+"workflow-"+part(workflowUrl, -1)))
+		workflow, err := GetWorkflow(org, repo, strconv.FormatInt(run.WorkflowId, 10))
 		if err != nil {
-			return err
+			return nil, err
 		}
-		table.Append([]string{
-			mns(run, "run_number"),
-			"#" + mns(run, "id"),
-			ms(run, "created_at"),
-			ms(workflow, "name"),
-			ms(run, "head_branch"),
-			limit(strings.Split(ms(run, "head_commit", "message"), "\n")[0], 50),
-			stepsAsString(l(m(jobs, "jobs"))),
+		result = append(result, runDetails{
+			Run:      run,
+			Workflow: workflow,
+			Jobs:     jobs.Jobs,
 		})
+	}
+	return result, nil
+}
 
+func printWorkflowRuns(org string, repo string, runs []WorkflowRun, format string) error {
+	details, err := readRunDetails(org, repo, runs)
+	if err != nil {
+		return err
 	}
-	table.Render()
+	if format != outputTable {
+		summaries := make([]runSummary, 0)
+		for _, detail := range details {
+			summaries = append(summaries, summarizeRun(detail.Run, detail.Workflow, detail.Jobs))
+		}
+		return printRunSummaries(os.Stdout, format, summaries)
+	}
+	println()
+	renderRunTable(os.Stdout, details, func(detail runDetails) string {
+		return stepsAsString(detail.Jobs)
+	})
 	return nil
 }
 
-func part(s string, i int) string {
-	urlParts := strings.Split(s, "/")
-	return urlParts[len(urlParts)+i]
-
-}
+//print the runs as a table, checks column is rendered by the steps function
+func renderRunTable(out io.Writer, details []runDetails, steps func(runDetails) string) {
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"#run", "id", "created", "workflow", "branch", "commit", "Checks"})
+	table.SetAutoWrapText(false)
 
+	for _, detail := range details {
+		run := detail.Run
+		table.Append([]string{
+			strconv.Itoa(run.RunNumber),
+			"#" + run.IdString(),
+			run.CreatedAt.Format(time.RFC3339),
+			detail.Workflow.Name,
+			run.HeadBranch,
+			limit(strings.Split(run.HeadCommit.Message, "\n")[0], 50),
+			steps(detail),
+		})
 
-func stepsAsString(jobs []interface{}) string {
-	groups := make([]string, 4)
+	}
+	table.Render()
+}
 
-	for _, job := range jobs {
-		name := m(job, "name").(string)
-		conclusion := nilsafe(m(job, "conclusion"))
-		status := m(job, "status").(string)
-
-		statusChr := "."
-		if strings.ToLower(status) != "completed" {
-			statusChr = "%"
-		} else {
-			if strings.ToLower(conclusion.(string)) == "success" {
-				statusChr = "_"
-			} else if strings.ToLower(conclusion.(string)) == "cancelled" {
-				statusChr = "~"
-			} else if strings.ToLower(conclusion.(string)) == "neutral" {
-				statusChr = " "
-			} else if strings.Contains(name, "(") {
-				statusChr = string(strings.TrimSpace(strings.Split(name, "(")[1])[0])
-			} else {
-				statusChr = string(name[0])
-			}
-		}
+//groups of the jobs, in the order of the stepsAsString output
+var jobGroups = []string{"basic", "integration", "acceptance", "other"}
+
+//index of the group of the job in jobGroups
+func jobGroupIndex(name string) int {
+	if strings.Contains(name, "integration") {
+		return 1
+	} else if strings.Contains(name, "acceptance") {
+		return 2
+	} else if strings.Contains(name, "kubernetes") || strings.Contains(name, "coverage") {
+		return 3
+	}
+	return 0
+}
 
-		groupIndex := 0
-		if strings.Contains(name, "integration") {
-			groupIndex = 1
-		} else if strings.Contains(name, "acceptance") {
-			groupIndex = 2
-		} else if strings.Contains(name, "kubernetes") || strings.Contains(name, "coverage") {
-			groupIndex = 3
+//one character representation of the job status
+func jobStatusChr(job Job) string {
+	name := job.Name
+	conclusion := strings.ToLower(job.Conclusion)
+
+	statusChr := "."
+	if strings.ToLower(job.Status) != "completed" {
+		statusChr = "%"
+	} else {
+		if conclusion == "success" {
+			statusChr = "_"
+		} else if conclusion == "cancelled" {
+			statusChr = "~"
+		} else if conclusion == "neutral" {
+			statusChr = " "
+		} else if strings.Contains(name, "(") {
+			statusChr = string(strings.TrimSpace(strings.Split(name, "(")[1])[0])
+		} else if name != "" {
+			statusChr = string(name[0])
 		}
-		groups[groupIndex] += statusChr
 	}
-	return strings.TrimSpace(strings.Join(groups, " "))
+	return statusChr
 }
 
-func buildStatus(pr interface{}) string {
-	jobs := make([]interface{}, 0)
+func stepsAsString(jobs []Job) string {
+	return formatSteps(jobs, func(job Job, statusChr string) string {
+		return statusChr
+	})
+}
 
-	for _, commitEdge := range l(m(pr, "commits", "edges")) {
-		commit := m(commitEdge, "node", "commit")
-		for _, suite := range l(m(commit, "checkSuites", "edges")) {
-			for _, runs := range l(m(suite, "node", "checkRuns", "edges")) {
-				node := m(runs, "node")
-				jobs = append(jobs, node)
-			}
+//status characters of the jobs grouped by the job groups, decorate can change the representation of one job
+func formatSteps(jobs []Job, decorate func(job Job, statusChr string) string) string {
+	groups := make([]string, len(jobGroups))
 
-		}
+	for _, job := range jobs {
+		groups[jobGroupIndex(job.Name)] += decorate(job, jobStatusChr(job))
 	}
-	return stepsAsString(jobs)
+	return strings.TrimSpace(strings.Join(groups, " "))
+}
+
+func buildStatus(pr GraphqlPullRequest) string {
+	return stepsAsString(pr.CheckRuns())
 }
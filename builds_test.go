@@ -7,16 +7,16 @@ import (
 
 func TestStepsAsString(t *testing.T) {
 
-	jobs := []interface{}{
-		map[string]interface{}{
-			"conclusion": "success",
-			"status":     "completed",
-			"name":       "basic (author)",
+	jobs := []Job{
+		{
+			Conclusion: "success",
+			Status:     "completed",
+			Name:       "basic (author)",
 		},
-		map[string]interface{}{
-			"conclusion": "failed",
-			"status":     "completed",
-			"name":       "basic (checkstyle)",
+		{
+			Conclusion: "failed",
+			Status:     "completed",
+			Name:       "basic (checkstyle)",
 		},
 	}
 
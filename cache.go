@@ -7,6 +7,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"reflect"
+	"runtime"
+	"strings"
 	"time"
 )
 
@@ -22,17 +25,15 @@ func buildResultCache(filename string) (bool, error) {
 		if err != nil {
 			return false, errors.Wrap(err, "Couldn't load the cachefile: "+filename)
 		}
-		jsonData := make(map[string]interface{})
-		err = json.Unmarshal(data, &jsonData)
+		jobs := Jobs{}
+		err = json.Unmarshal(data, &jobs)
 		if err != nil {
 			return false, errors.Wrap(err, "Couldn't parse the cachefile to json: "+filename)
 		}
 
 		//in case of any uncompleted job, we need to refresh it
-		for _, job := range l(m(jsonData, "jobs")) {
-			if ms(job, "status") != "completed" {
-				return timeCache3min(filename)
-			}
+		if !jobs.Completed() {
+			return timeCache3min(filename)
 		}
 		return true, nil
 	}
@@ -43,15 +44,48 @@ func cachedGet3min(getter getter, key string) ([]byte, error) {
 }
 
 func timeCache3min(cacheFile string) (bool, error) {
-	if stat, err := os.Stat(cacheFile); !os.IsNotExist(err) {
-		if stat.ModTime().Add(3 * time.Minute).After(time.Now()) {
+	if fetchedAt, found := cacheFetchTime(cacheFile); found {
+		if fetchedAt.Add(3 * time.Minute).After(time.Now()) {
 			return true, nil
 		}
 	}
 	return false, nil
 }
 
-func cachedGet(getter getter, key string, cacheValidator isCacheValid) ([]byte, error) {
+//for rarely changing data (like the list of the workflows)
+func timeCache1day(cacheFile string) (bool, error) {
+	if fetchedAt, found := cacheFetchTime(cacheFile); found {
+		if fetchedAt.Add(24 * time.Hour).After(time.Now()) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+//validators of a cached response which can be used for conditional requests
+type cacheValidators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+func (v cacheValidators) empty() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+type conditionalResponse struct {
+	Url    string
+	Status int
+	Body   []byte
+	//validators of the new response (empty, if the response couldn't be validated later)
+	Validators cacheValidators
+	//true if the server answered 304: the cached body is still up-to-date
+	NotModified bool
+}
+
+//getter which can send a conditional request based on the validators of the cached entry
+type conditionalGetter func(validators cacheValidators) (conditionalResponse, error)
+
+func cacheDir() string {
 	oghCache := os.Getenv("OGH_CACHE")
 
 	if oghCache == "" {
@@ -60,29 +94,223 @@ func cachedGet(getter getter, key string, cacheValidator isCacheValid) ([]byte,
 			oghCache = path.Join(home, ".cache", "ogh")
 		}
 	}
+	return oghCache
+}
+
+//metadata of a cache entry, saved next to the cached body
+type cacheMeta struct {
+	Url string `json:"url,omitempty"`
+	//time of the last download or revalidation
+	FetchedAt time.Time `json:"fetchedAt"`
+	//http status of the last download or revalidation
+	Status int `json:"status,omitempty"`
+	//name of the isCacheValid function which decides about the freshness of the entry
+	Policy     string          `json:"policy"`
+	Validators cacheValidators `json:"validators"`
+}
+
+const cacheMetaSuffix = ".meta"
+const cacheLockSuffix = ".lock"
+
+func cacheMetaFile(cacheFile string) string {
+	return cacheFile + cacheMetaSuffix
+}
+
+func readCacheMeta(cacheFile string) cacheMeta {
+	meta := cacheMeta{}
+	data, err := ioutil.ReadFile(cacheMetaFile(cacheFile))
+	if err != nil {
+		return meta
+	}
+	err = json.Unmarshal(data, &meta)
+	if err != nil {
+		log.Debug().Msgf("Ignoring invalid metadata of %s: %s", cacheFile, err.Error())
+	}
+	return meta
+}
+
+func writeCacheMeta(cacheFile string, meta cacheMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(cacheMetaFile(cacheFile), data)
+}
+
+//time of the last download/revalidation of the entry (modification time for entries without metadata)
+func cacheFetchTime(cacheFile string) (time.Time, bool) {
+	stat, err := os.Stat(cacheFile)
+	if err != nil {
+		return time.Time{}, false
+	}
+	meta := readCacheMeta(cacheFile)
+	if !meta.FetchedAt.IsZero() {
+		return meta.FetchedAt, true
+	}
+	return stat.ModTime(), true
+}
+
+//write the file to a temporary file first and rename it, readers never see partially written files
+func writeFileAtomic(file string, data []byte) error {
+	tmp, err := ioutil.TempFile(path.Dir(file), "."+path.Base(file)+".tmp")
+	if err != nil {
+		return err
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), file)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return errors.Wrap(err, "Couldn't write "+file)
+	}
+	return nil
+}
+
+//name of a cache policy function (eg. timeCache3min)
+func policyName(cacheValidator isCacheValid) string {
+	name := runtime.FuncForPC(reflect.ValueOf(cacheValidator).Pointer()).Name()
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+//cache key of a repository specific entry, entries of the same repository (of the same Github instance) are stored in the same directory
+func repoCacheKey(org string, repo string, name string) string {
+	return path.Join(apiHost(), org, repo, strings.ReplaceAll(name, "/", "_"))
+}
+
+//remove a cache entry (with its metadata), eg. after a change which makes it outdated
+func invalidateCacheEntry(key string) error {
+	oghCache := cacheDir()
+	if oghCache == "" {
+		return nil
+	}
+	cacheFile := path.Join(oghCache, key)
+	for _, file := range []string{cacheFile, cacheMetaFile(cacheFile)} {
+		err := os.Remove(file)
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "Couldn't remove the cache entry "+key)
+		}
+	}
+	return nil
+}
+
+//if true, cached entries are not used (but the fresh responses are written to the cache)
+var refreshCache = false
+
+//run the reads without using the cached entries (eg. before a mutating call), fresh responses are still cached
+func withoutCache(read func() error) error {
+	previous := refreshCache
+	refreshCache = true
+	defer func() { refreshCache = previous }()
+	return read()
+}
+
+//if true, everything is served from the cache (regardless of the age of the entries) without using the network
+var offline = false
+
+func cachedGet(getter getter, key string, cacheValidator isCacheValid) ([]byte, error) {
+	return cachedConditionalGet(func(validators cacheValidators) (conditionalResponse, error) {
+		body, err := getter()
+		return conditionalResponse{Body: body}, err
+	}, key, cacheValidator)
+}
+
+func cachedConditionalGet(getter conditionalGetter, key string, cacheValidator isCacheValid) ([]byte, error) {
+	oghCache := cacheDir()
 	cacheFile := ""
 	if oghCache != "" {
 		cacheFile = path.Join(oghCache, key)
 	}
 
-	if cacheFile != "" {
-		_ = os.MkdirAll(oghCache, 0700)
-
-		valid, err := cacheValidator(cacheFile)
-		if err != nil {
-			println("Couldn't validate cache file " + cacheFile + " " + err.Error())
+	if offline {
+		if cacheFile == "" {
+			return nil, errors.New("Cache directory couldn't be determined, it's required in offline mode")
 		}
-		if err == nil && valid {
-			log.Debug().Msgf("'%s' is read from the cache", key)
-			return ioutil.ReadFile(cacheFile)
+		data, err := ioutil.ReadFile(cacheFile)
+		if os.IsNotExist(err) {
+			return nil, errors.New("'" + key + "' is not cached, it can't be downloaded in offline mode")
 		}
+		return data, err
+	}
+
+	if cacheFile == "" {
+		response, err := getter(cacheValidators{})
+		return response.Body, err
 	}
-	result, err := getter()
-	if err == nil && cacheFile != "" {
-		err = ioutil.WriteFile(cacheFile, result, 0600)
+
+	if data, found := readValidCacheEntry(cacheFile, key, cacheValidator); found {
+		return data, nil
+	}
+
+	err := os.MkdirAll(path.Dir(cacheFile), 0700)
+	if err != nil {
+		return nil, err
+	}
+	unlock, err := lockFile(cacheFile + cacheLockSuffix)
+	if err != nil {
+		return nil, errors.Wrap(err, "Couldn't lock the cache entry "+key)
+	}
+	defer unlock()
+
+	//the entry may be downloaded by an other process while we were waiting for the lock
+	if data, found := readValidCacheEntry(cacheFile, key, cacheValidator); found {
+		return data, nil
+	}
+
+	validators := cacheValidators{}
+	if _, err := os.Stat(cacheFile); err == nil && !refreshCache {
+		validators = readCacheMeta(cacheFile).Validators
+	}
+	response, err := getter(validators)
+	if err != nil {
+		return nil, err
+	}
+	meta := cacheMeta{
+		Url:        response.Url,
+		FetchedAt:  time.Now(),
+		Status:     response.Status,
+		Policy:     policyName(cacheValidator),
+		Validators: response.Validators,
+	}
+	if response.NotModified {
+		log.Debug().Msgf("'%s' is not modified, cache entry is refreshed", key)
+		err = writeCacheMeta(cacheFile, meta)
 		if err != nil {
 			return nil, err
 		}
+		return ioutil.ReadFile(cacheFile)
+	}
+	err = writeFileAtomic(cacheFile, response.Body)
+	if err != nil {
+		return nil, err
+	}
+	err = writeCacheMeta(cacheFile, meta)
+	if err != nil {
+		return nil, err
+	}
+	return response.Body, nil
+}
+
+//returns with the content of the cache entry if it's still valid
+func readValidCacheEntry(cacheFile string, key string, cacheValidator isCacheValid) ([]byte, bool) {
+	if refreshCache {
+		return nil, false
+	}
+	valid, err := cacheValidator(cacheFile)
+	if err != nil {
+		println("Couldn't validate cache file " + cacheFile + " " + err.Error())
+		return nil, false
+	}
+	if !valid {
+		return nil, false
+	}
+	data, err := ioutil.ReadFile(cacheFile)
+	if err != nil {
+		return nil, false
 	}
-	return result, err
+	log.Debug().Msgf("'%s' is read from the cache", key)
+	return data, true
 }
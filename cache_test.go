@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func alwaysStale(string) (bool, error) {
+	return false, nil
+}
+
+func TestConditionalCachedGet(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ogh-cache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	defer os.Setenv("OGH_CACHE", os.Getenv("OGH_CACHE"))
+	_ = os.Setenv("OGH_CACHE", dir)
+
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads++
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"name": "post-commit"}`))
+	}))
+	defer server.Close()
+
+	for i := 0; i < 3; i++ {
+		body, err := cachedConditionalGet(restGetter(server.URL), "test-workflow", alwaysStale)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"name": "post-commit"}`, string(body))
+	}
+	assert.Equal(t, 1, downloads)
+}
+
+func TestConditionalCachedGetOfPages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ogh-cache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	defer os.Setenv("OGH_CACHE", os.Getenv("OGH_CACHE"))
+	_ = os.Setenv("OGH_CACHE", dir)
+
+	version := "v1"
+	requests := make([]string, 0)
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requests = append(requests, page)
+		if page == "2" {
+			_, _ = w.Write([]byte(`{"total_count": 2, "jobs": [{"name": "b"}]}`))
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"`+version+`"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"`+version+`"`)
+		w.Header().Add("Link", "<"+server.URL+"/jobs?page=2>; rel=\"next\"")
+		_, _ = w.Write([]byte(`{"total_count": 2, "jobs": [{"name": "a"}]}`))
+	}))
+	defer server.Close()
+
+	read := func() {
+		body, err := cachedConditionalGet(restListGetter(server.URL+"/jobs", "jobs"), "test-jobs", alwaysStale)
+		assert.Nil(t, err)
+		jobs := Jobs{}
+		assert.Nil(t, json.Unmarshal(body, &jobs))
+		assert.Len(t, jobs.Jobs, 2)
+	}
+
+	read()
+	//the first page is not modified, the cached list is used
+	read()
+	assert.Equal(t, []string{"", "2", ""}, requests)
+
+	//the first page is changed, all the pages are downloaded
+	version = "v2"
+	read()
+	assert.Equal(t, []string{"", "2", "", "", "2"}, requests)
+}
+
+func TestRefreshAndOfflineCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ogh-cache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	defer os.Setenv("OGH_CACHE", os.Getenv("OGH_CACHE"))
+	_ = os.Setenv("OGH_CACHE", dir)
+
+	calls := 0
+	getter := func() ([]byte, error) {
+		calls++
+		return []byte("response"), nil
+	}
+
+	_, err = cachedGet3min(getter, "apache/ozone/key")
+	assert.Nil(t, err)
+	_, err = cachedGet3min(getter, "apache/ozone/key")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, calls)
+
+	refreshCache = true
+	_, err = cachedGet3min(getter, "apache/ozone/key")
+	refreshCache = false
+	assert.Nil(t, err)
+	assert.Equal(t, 2, calls)
+
+	offline = true
+	defer func() { offline = false }()
+	body, err := cachedGet(getter, "apache/ozone/key", alwaysStale)
+	assert.Nil(t, err)
+	assert.Equal(t, "response", string(body))
+	_, err = cachedGet(getter, "apache/ozone/other", alwaysStale)
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"fmt"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//known cache policies, to check the freshness of an entry based on the policy name of the metadata
+var cachePolicies = map[string]isCacheValid{
+	"timeCache3min":    timeCache3min,
+	"timeCache1day":    timeCache1day,
+	"buildResultCache": buildResultCache,
+}
+
+type cacheEntry struct {
+	Key       string
+	Size      int64
+	FetchedAt time.Time
+}
+
+//prefix of the key which is used to group the entries (host/org/repo for repository specific entries)
+func (entry cacheEntry) prefix() string {
+	parts := strings.Split(entry.Key, "/")
+	if len(parts) > 3 {
+		return strings.Join(parts[:3], "/")
+	}
+	return "(other)"
+}
+
+//list all the cache entries under the root directory (metadata files are counted to the size of the entry)
+func listCacheEntries(root string) ([]cacheEntry, error) {
+	entries := make([]cacheEntry, 0)
+	metaSizes := make(map[string]int64)
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && file == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		key, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		key = filepath.ToSlash(key)
+		if strings.HasSuffix(key, cacheMetaSuffix) {
+			metaSizes[strings.TrimSuffix(key, cacheMetaSuffix)] += info.Size()
+			return nil
+		}
+		//lock files and temporary files of the atomic writes
+		if strings.HasSuffix(key, cacheLockSuffix) || strings.HasPrefix(info.Name(), ".") {
+			return nil
+		}
+		fetchedAt, _ := cacheFetchTime(file)
+		entries = append(entries, cacheEntry{
+			Key:       key,
+			Size:      info.Size(),
+			FetchedAt: fetchedAt,
+		})
+		return nil
+	})
+	for i := range entries {
+		entries[i].Size += metaSizes[entries[i].Key]
+	}
+	return entries, err
+}
+
+func humanSize(size int64) string {
+	units := []string{"B", "KB", "MB", "GB"}
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return strconv.FormatInt(size, 10) + " B"
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
+
+func requireCacheDir() (string, error) {
+	root := cacheDir()
+	if root == "" {
+		return "", errors.New("Cache directory couldn't be determined. Please set OGH_CACHE")
+	}
+	return root, nil
+}
+
+//print the size and the number of the cache entries grouped by org/repo
+func printCacheSummary() error {
+	root, err := requireCacheDir()
+	if err != nil {
+		return err
+	}
+	entries, err := listCacheEntries(root)
+	if err != nil {
+		return err
+	}
+
+	counts := make(map[string]int)
+	sizes := make(map[string]int64)
+	var total int64
+	for _, entry := range entries {
+		counts[entry.prefix()]++
+		sizes[entry.prefix()] += entry.Size
+		total += entry.Size
+	}
+	prefixes := make([]string, 0)
+	for prefix := range counts {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	fmt.Println("Cache directory: " + root)
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Prefix", "Entries", "Size"})
+	table.SetAutoWrapText(false)
+	for _, prefix := range prefixes {
+		table.Append([]string{prefix, strconv.Itoa(counts[prefix]), humanSize(sizes[prefix])})
+	}
+	table.SetFooter([]string{"Total", strconv.Itoa(len(entries)), humanSize(total)})
+	table.Render()
+	return nil
+}
+
+//print the details of one cache entry
+func inspectCacheEntry(key string) error {
+	root, err := requireCacheDir()
+	if err != nil {
+		return err
+	}
+	cacheFile := path.Join(root, key)
+	stat, err := os.Stat(cacheFile)
+	if err != nil {
+		return errors.Wrap(err, "Cache entry couldn't be found: "+key)
+	}
+	meta := readCacheMeta(cacheFile)
+	fetchedAt, _ := cacheFetchTime(cacheFile)
+
+	fmt.Printf("Key:           %s\n", key)
+	fmt.Printf("File:          %s\n", cacheFile)
+	if meta.Url != "" {
+		fmt.Printf("Url:           %s\n", meta.Url)
+	}
+	fmt.Printf("Size:          %s\n", humanSize(stat.Size()))
+	fmt.Printf("Fetched at:    %s\n", fetchedAt.Format(time.RFC3339))
+	fmt.Printf("Age:           %s\n", time.Now().Sub(fetchedAt).Round(time.Second))
+	if meta.Status != 0 {
+		fmt.Printf("Status:        %d\n", meta.Status)
+	}
+	if meta.Policy == "" {
+		fmt.Printf("Policy:        unknown\n")
+	} else {
+		fmt.Printf("Policy:        %s\n", meta.Policy)
+		if policy, found := cachePolicies[meta.Policy]; found {
+			valid, err := policy(cacheFile)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Fresh:         %t\n", valid)
+		}
+	}
+	if meta.Validators.ETag != "" {
+		fmt.Printf("ETag:          %s\n", meta.Validators.ETag)
+	}
+	if meta.Validators.LastModified != "" {
+		fmt.Printf("Last-Modified: %s\n", meta.Validators.LastModified)
+	}
+	return nil
+}
+
+//remove the entry with its metadata and lock file
+func removeCacheEntry(root string, entry cacheEntry) error {
+	cacheFile := path.Join(root, entry.Key)
+	err := os.Remove(cacheFile)
+	if err != nil {
+		return err
+	}
+	for _, file := range []string{cacheMetaFile(cacheFile), cacheFile + cacheLockSuffix} {
+		err = os.Remove(file)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+//files which are not needed any more: temporary files of interrupted writes and lock files without entry
+func orphanedCacheFiles(root string, olderThan time.Time) ([]string, error) {
+	files := make([]string, 0)
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && file == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		//files of in-progress downloads are not touched
+		if info.IsDir() || !info.ModTime().Before(olderThan) {
+			return nil
+		}
+		if strings.HasPrefix(info.Name(), ".") && strings.Contains(info.Name(), ".tmp") {
+			files = append(files, file)
+		} else if strings.HasSuffix(file, cacheLockSuffix) {
+			if _, err := os.Stat(strings.TrimSuffix(file, cacheLockSuffix)); os.IsNotExist(err) {
+				files = append(files, file)
+			}
+		}
+		return nil
+	})
+	return files, err
+}
+
+//remove all the entries which are older than the given days
+func pruneCache(days int) error {
+	root, err := requireCacheDir()
+	if err != nil {
+		return err
+	}
+	entries, err := listCacheEntries(root)
+	if err != nil {
+		return err
+	}
+	threshold := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+	removed := 0
+	var freed int64
+	for _, entry := range entries {
+		if entry.FetchedAt.Before(threshold) {
+			err = removeCacheEntry(root, entry)
+			if err != nil {
+				return err
+			}
+			removed++
+			freed += entry.Size
+		}
+	}
+	//atomic writes and locks are short, older files are left behind by interrupted processes
+	orphaned, err := orphanedCacheFiles(root, time.Now().Add(-time.Hour))
+	if err != nil {
+		return err
+	}
+	for _, file := range orphaned {
+		err = os.Remove(file)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	fmt.Printf("%d cache entries (%s) and %d orphaned files are removed\n", removed, humanSize(freed), len(orphaned))
+	return nil
+}
+
+//remove all the cache entries of one repository
+func clearCache(org string, repo string) error {
+	root, err := requireCacheDir()
+	if err != nil {
+		return err
+	}
+	repoDir := path.Join(root, apiHost(), org, repo)
+	entries, err := listCacheEntries(repoDir)
+	if err != nil {
+		return err
+	}
+	err = os.RemoveAll(repoDir)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d cache entries of %s/%s are removed\n", len(entries), org, repo)
+	return nil
+}
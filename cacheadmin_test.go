@@ -0,0 +1,64 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListAndPruneCacheEntries(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ogh-cache")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	defer os.Setenv("OGH_CACHE", os.Getenv("OGH_CACHE"))
+	_ = os.Setenv("OGH_CACHE", dir)
+
+	for _, key := range []string{repoCacheKey("apache", "ozone", "pulls-1"), repoCacheKey("apache", "ozone", "pulls-2"), repoCacheKey("elek", "ozone", "pulls-3")} {
+		_, err := cachedGet(func() ([]byte, error) {
+			return []byte("{}"), nil
+		}, key, timeCache3min)
+		assert.Nil(t, err)
+	}
+	oldEntry := path.Join(dir, "api.github.com/apache/ozone/pulls-1")
+	meta := readCacheMeta(oldEntry)
+	meta.FetchedAt = time.Now().Add(-10 * 24 * time.Hour)
+	assert.Nil(t, writeCacheMeta(oldEntry, meta))
+
+	entries, err := listCacheEntries(dir)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "api.github.com/apache/ozone", entries[0].prefix())
+	assert.Equal(t, "timeCache3min", readCacheMeta(path.Join(dir, "api.github.com/apache/ozone/pulls-2")).Policy)
+
+	//leftovers of an interrupted process
+	old := time.Now().Add(-2 * time.Hour)
+	repoDir := path.Join(dir, "api.github.com/apache/ozone")
+	for _, file := range []string{".pulls-2.tmp123", "pulls-9.lock"} {
+		assert.Nil(t, ioutil.WriteFile(path.Join(repoDir, file), []byte{}, 0600))
+		assert.Nil(t, os.Chtimes(path.Join(repoDir, file), old, old))
+	}
+	assert.Nil(t, ioutil.WriteFile(path.Join(repoDir, ".pulls-3.tmp456"), []byte{}, 0600))
+
+	assert.Nil(t, pruneCache(7))
+	entries, err = listCacheEntries(dir)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+	remaining := make([]string, 0)
+	files, err := ioutil.ReadDir(repoDir)
+	assert.Nil(t, err)
+	for _, file := range files {
+		remaining = append(remaining, file.Name())
+	}
+	//the new temporary file can belong to a running process
+	assert.Equal(t, []string{".pulls-3.tmp456", "pulls-2", "pulls-2.lock", "pulls-2.meta"}, remaining)
+
+	assert.Nil(t, clearCache("apache", "ozone"))
+	entries, err = listCacheEntries(dir)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "api.github.com/elek/ozone/pulls-3", entries[0].Key)
+}
@@ -0,0 +1,25 @@
+// +build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+//exclusive lock on the file (blocks until the lock is acquired). Returns the function to release the lock.
+func lockFile(file string) (func(), error) {
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	err = syscall.Flock(int(f.Fd()), syscall.LOCK_EX)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}, nil
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+type cancelOptions struct {
+	//cancel only the unfinished runs of the older commits of the branch
+	Superseded bool
+	//the reference identifies a branch (instead of a pull request or run)
+	Branch bool
+}
+
+//unfinished runs, except the runs of the latest commit in case of superseded
+func cancellableRuns(runs []WorkflowRun, superseded bool, latestSha string) []WorkflowRun {
+	result := make([]WorkflowRun, 0)
+	for _, run := range runs {
+		if run.Status == "completed" {
+			continue
+		}
+		if superseded && run.HeadSha == latestSha {
+			continue
+		}
+		result = append(result, run)
+	}
+	return result
+}
+
+//runs which are started by pushing to the repository of the run (runs of the same branch name in other forks are ignored).
+//The name of the repository is coming from the run, as the requested name can be an old name of a renamed repository.
+func runsOfRepository(runs []WorkflowRun) []WorkflowRun {
+	result := make([]WorkflowRun, 0)
+	for _, run := range runs {
+		if strings.EqualFold(run.HeadRepository.FullName, run.Repository.FullName) {
+			result = append(result, run)
+		}
+	}
+	return result
+}
+
+//runs of the pull request: runs linked to the pull request or pushed to its head repository
+func runsOfPr(runs []WorkflowRun, pr PullRequest) []WorkflowRun {
+	headRepo := ""
+	if pr.Head.Repo != nil {
+		headRepo = pr.Head.Repo.FullName
+	}
+	result := make([]WorkflowRun, 0)
+	for _, run := range runs {
+		linked := false
+		for _, runPr := range run.PullRequests {
+			linked = linked || runPr.Number == pr.Number
+		}
+		if linked || (headRepo != "" && strings.EqualFold(run.HeadRepository.FullName, headRepo)) {
+			result = append(result, run)
+		}
+	}
+	return result
+}
+
+//workflow runs of the referenced run, pull request or branch and the sha of the latest commit
+func runsToCancel(ref Reference, options cancelOptions) ([]WorkflowRun, string, error) {
+	if ref.Kind == referenceRun {
+		if options.Superseded {
+			return nil, "", errors.New("--superseded can be used only with branch or pull request references")
+		}
+		run, err := GetWorkflowRun(ref.Org, ref.Repo, ref.Id)
+		if err != nil {
+			return nil, "", err
+		}
+		return []WorkflowRun{run}, run.HeadSha, nil
+	}
+
+	if options.Branch {
+		runs, err := readBuilds(Reference{Org: ref.Org, Repo: ref.Repo, Branch: ref.Branch}, "")
+		if err != nil {
+			return nil, "", err
+		}
+		runs = runsOfRepository(runs)
+		if len(runs) == 0 {
+			return runs, "", nil
+		}
+		return runs, runs[0].HeadSha, nil
+	}
+
+	pr, err := GetPr(ref.Org, ref.Repo, ref.Id)
+	if err != nil {
+		return nil, "", err
+	}
+	if options.Superseded {
+		runs, err := readBuilds(Reference{Org: ref.Org, Repo: ref.Repo, Branch: pr.Head.Ref}, "")
+		return runsOfPr(runs, pr), pr.Head.Sha, err
+	}
+	runs, err := GetWorkflowRunsOfSha(ref.Org, ref.Repo, pr.Head.Sha)
+	return runs.WorkflowRuns, pr.Head.Sha, err
+}
+
+//cancel the unfinished workflow runs of a pull request, branch or run
+func cancel(ref Reference, options cancelOptions) error {
+	var err error
+	if !options.Branch {
+		ref, err = resolvePrReference(ref)
+		if err != nil {
+			return err
+		}
+	}
+	//the cached lists can be outdated, runs are cancelled based on fresh data
+	var runs []WorkflowRun
+	latestSha := ""
+	err = withoutCache(func() error {
+		runs, latestSha, err = runsToCancel(ref, options)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	runs = cancellableRuns(runs, options.Superseded, latestSha)
+	if len(runs) == 0 {
+		fmt.Println("There are no unfinished workflow runs to cancel")
+		return nil
+	}
+	failed := make([]string, 0)
+	for _, run := range runs {
+		err := postRunAction(apiUrl("/repos/") + ref.Org + "/" + ref.Repo + "/actions/runs/" + run.IdString() + "/cancel")
+		if err != nil {
+			//the run can be finished since it's listed
+			failed = append(failed, run.IdString())
+			fmt.Printf("%s #%d (%s) couldn't be cancelled: %s\n", run.Name, run.RunNumber, run.HeadBranch, err.Error())
+			continue
+		}
+		fmt.Printf("%s #%d (%s) is cancelled: %s\n", run.Name, run.RunNumber, run.HeadBranch, run.HtmlUrl)
+	}
+	if len(failed) > 0 {
+		return errors.New("Some of the workflow runs couldn't be cancelled: " + strings.Join(failed, ", "))
+	}
+	return nil
+}
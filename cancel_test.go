@@ -0,0 +1,83 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancellableRuns(t *testing.T) {
+	runs := []WorkflowRun{
+		{Id: 3, HeadSha: "new", Status: "in_progress"},
+		{Id: 2, HeadSha: "old", Status: "queued"},
+		{Id: 1, HeadSha: "old", Status: "completed"},
+	}
+
+	assert.Equal(t, []WorkflowRun{runs[0], runs[1]}, cancellableRuns(runs, false, "new"))
+	assert.Equal(t, []WorkflowRun{runs[1]}, cancellableRuns(runs, true, "new"))
+}
+
+func TestCancelSuperseded(t *testing.T) {
+	posts := make([]string, 0)
+	cleanup := withFakeGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			posts = append(posts, r.URL.Path)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		switch r.URL.Path {
+		case "/repos/apache/ozone/pulls/123":
+			_, _ = w.Write([]byte(`{"number": 123, "head": {"ref": "HDDS-1", "sha": "new", "repo": {"full_name": "elek/ozone"}}}`))
+		case "/repos/apache/ozone/actions/runs":
+			assert.Equal(t, "HDDS-1", r.URL.Query().Get("branch"))
+			_, _ = w.Write([]byte(`{"total_count": 4, "workflow_runs": [
+				{"id": 4, "head_sha": "new", "status": "in_progress", "head_repository": {"full_name": "elek/ozone"}},
+				{"id": 3, "head_sha": "other", "status": "in_progress", "head_repository": {"full_name": "someone/ozone"}},
+				{"id": 2, "head_sha": "old", "status": "queued", "head_repository": {"full_name": "elek/ozone"}},
+				{"id": 1, "head_sha": "old", "status": "completed", "head_repository": {"full_name": "elek/ozone"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer cleanup()
+
+	ref := Reference{Org: "apache", Repo: "ozone", Id: "123", Kind: referencePr}
+	err := cancel(ref, cancelOptions{Superseded: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/repos/apache/ozone/actions/runs/2/cancel"}, posts)
+}
+
+func TestRunsOfPr(t *testing.T) {
+	pr := PullRequest{Number: 123, Head: GitRef{Repo: &Repository{FullName: "elek/ozone"}}}
+	repo := Repository{FullName: "apache/ozone"}
+	runs := []WorkflowRun{
+		{Id: 1, Repository: repo, HeadRepository: Repository{FullName: "Elek/ozone"}},
+		{Id: 2, Repository: repo, HeadRepository: Repository{FullName: "apache/ozone"}, PullRequests: []RunPullRequest{{Number: 123}}},
+		{Id: 3, Repository: repo, HeadRepository: Repository{FullName: "someone/ozone"}},
+	}
+
+	assert.Equal(t, []WorkflowRun{runs[0], runs[1]}, runsOfPr(runs, pr))
+	assert.Equal(t, []WorkflowRun{runs[1]}, runsOfRepository(runs))
+}
+
+func TestCancelBranchOfRenamedRepository(t *testing.T) {
+	posts := make([]string, 0)
+	cleanup := withFakeGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			posts = append(posts, r.URL.Path)
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		//the requests of the old name are redirected by github, runs report the new name
+		_, _ = w.Write([]byte(`{"total_count": 2, "workflow_runs": [
+			{"id": 2, "head_sha": "new", "status": "in_progress", "repository": {"full_name": "apache/ozone"}, "head_repository": {"full_name": "apache/ozone"}},
+			{"id": 1, "head_sha": "other", "status": "in_progress", "repository": {"full_name": "apache/ozone"}, "head_repository": {"full_name": "elek/ozone"}}]}`))
+	})
+	defer cleanup()
+
+	ref := Reference{Org: "apache", Repo: "hadoop-ozone", Branch: "master"}
+	err := cancel(ref, cancelOptions{Branch: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/repos/apache/hadoop-ozone/actions/runs/2/cancel"}, posts)
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/osfs"
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/pkg/errors"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//github repository and branch of a local git checkout
+type checkout struct {
+	//org/repo of the upstream repository (or origin if there is no upstream remote)
+	Org  string
+	Repo string
+	//owner of the origin remote (differs from Org if origin is a fork)
+	ForkOrg string
+	//current branch (empty in case of detached HEAD)
+	Branch string
+}
+
+//returns with the org and repo of a github remote url (https://github.com/org/repo.git or git@github.com:org/repo.git)
+func parseRemoteUrl(remoteUrl string) (string, string, bool) {
+	remotePath := remoteUrl
+	if strings.Contains(remoteUrl, "://") {
+		parsed, err := url.Parse(remoteUrl)
+		if err != nil {
+			return "", "", false
+		}
+		remotePath = parsed.Path
+	} else if strings.Contains(remoteUrl, ":") {
+		remotePath = remoteUrl[strings.Index(remoteUrl, ":")+1:]
+	} else {
+		//local path
+		return "", "", false
+	}
+	parts := strings.Split(strings.Trim(strings.TrimSuffix(remotePath, ".git"), "/"), "/")
+	if len(parts) < 2 || parts[len(parts)-2] == "" || parts[len(parts)-1] == "" {
+		return "", "", false
+	}
+	return parts[len(parts)-2], parts[len(parts)-1], true
+}
+
+//detect the github repository and branch of the git checkout which contains the directory
+func detectCheckout(dir string) (checkout, error) {
+	result := checkout{}
+	gitDir := findGitDir(dir)
+	if gitDir == "" {
+		return result, errors.New(dir + " is not inside a git repository")
+	}
+	st := filesystem.NewStorage(osfs.New(gitDir), cache.NewObjectLRUDefault())
+	repository, err := git.Open(st, memfs.New())
+	if err != nil {
+		return result, errors.Wrap(err, "Couldn't open the git repository "+gitDir)
+	}
+
+	remotes, err := repository.Remotes()
+	if err != nil {
+		return result, err
+	}
+	remoteRepos := make(map[string][]string)
+	for _, remote := range remotes {
+		if len(remote.Config().URLs) == 0 {
+			continue
+		}
+		if org, repo, ok := parseRemoteUrl(remote.Config().URLs[0]); ok {
+			remoteRepos[remote.Config().Name] = []string{org, repo}
+		}
+	}
+	origin, found := remoteRepos["origin"]
+	if found {
+		result.Org, result.Repo = origin[0], origin[1]
+		result.ForkOrg = origin[0]
+	}
+	if upstream, found := remoteRepos["upstream"]; found {
+		result.Org, result.Repo = upstream[0], upstream[1]
+	}
+	if result.Org == "" {
+		return result, errors.New("The git repository doesn't have any github origin/upstream remote")
+	}
+
+	//HEAD is read as a symbolic reference to support branches without commits
+	head, err := repository.Storer.Reference(plumbing.HEAD)
+	if err == nil && head.Type() == plumbing.SymbolicReference && head.Target().IsBranch() {
+		result.Branch = head.Target().Short()
+	}
+	return result, nil
+}
+
+//default reference of the commands which work on the current checkout (builds, artifacts, rerun):
+//org/repo of the checkout (unless .ogh.yaml defines them) and the current branch (even if .ogh.yaml defines a branch)
+func checkoutDefaults(dir string) Reference {
+	ref := Reference{
+		Org:    currentConfig.Org,
+		Repo:   currentConfig.Repo,
+		Branch: currentConfig.Branch,
+	}
+	co, err := detectCheckout(dir)
+	if err != nil {
+		return ref
+	}
+	project, err := readConfig(path.Join(filepath.Dir(findGitDir(dir)), ".ogh.yaml"))
+	if err != nil || (project.Org == "" && project.Repo == "") {
+		ref.Org = co.Org
+		ref.Repo = co.Repo
+	}
+	if co.Branch != "" {
+		ref.Branch = co.Branch
+	}
+	return ref
+}
+
+//returns with the open pull request of the current branch of the checkout
+func findPrOfCheckout(co checkout) (PullRequest, error) {
+	if co.Branch == "" {
+		return PullRequest{}, errors.New("The pull request can't be detected without a checked out branch")
+	}
+	owner := co.ForkOrg
+	if owner == "" {
+		owner = co.Org
+	}
+	prs, err := GetPrsOfBranch(co.Org, co.Repo, owner, co.Branch)
+	if err != nil {
+		return PullRequest{}, err
+	}
+	if len(prs) == 0 {
+		return PullRequest{}, errors.New("No open pull request is found for the branch " + owner + ":" + co.Branch)
+	}
+	return prs[0], nil
+}
+
+//fill the pull request id of the reference from the current checkout (if it's not defined)
+func resolvePrReference(ref Reference) (Reference, error) {
+	if ref.Id != "" {
+		return ref, nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return ref, err
+	}
+	co, err := detectCheckout(wd)
+	if err != nil {
+		return ref, errors.Wrap(err, "Pull request is not specified and it couldn't be detected")
+	}
+	pr, err := findPrOfCheckout(co)
+	if err != nil {
+		return ref, err
+	}
+	ref.Org = co.Org
+	ref.Repo = co.Repo
+	ref.Branch = co.Branch
+	ref.Id = strconv.Itoa(pr.Number)
+	ref.Kind = referencePr
+	return ref, nil
+}
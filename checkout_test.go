@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	git "github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRemoteUrl(t *testing.T) {
+	for _, remote := range []string{
+		"https://github.com/apache/ozone.git",
+		"https://github.com/apache/ozone",
+		"git@github.com:apache/ozone.git",
+		"ssh://git@github.com/apache/ozone.git",
+	} {
+		org, repo, ok := parseRemoteUrl(remote)
+		assert.True(t, ok, remote)
+		assert.Equal(t, "apache", org, remote)
+		assert.Equal(t, "ozone", repo, remote)
+	}
+	_, _, ok := parseRemoteUrl("/tmp/ozone")
+	assert.False(t, ok)
+}
+
+func TestDetectCheckout(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ogh-checkout")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	repository, err := git.PlainInit(dir, false)
+	assert.Nil(t, err)
+	_, err = repository.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{"git@github.com:elek/ozone.git"}})
+	assert.Nil(t, err)
+	assert.Nil(t, repository.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("HDDS-1234"))))
+	assert.Nil(t, os.MkdirAll(path.Join(dir, "hadoop-ozone"), 0700))
+
+	co, err := detectCheckout(path.Join(dir, "hadoop-ozone"))
+	assert.Nil(t, err)
+	assert.Equal(t, checkout{Org: "elek", Repo: "ozone", ForkOrg: "elek", Branch: "HDDS-1234"}, co)
+
+	_, err = repository.CreateRemote(&gitconfig.RemoteConfig{Name: "upstream", URLs: []string{"https://github.com/apache/ozone.git"}})
+	assert.Nil(t, err)
+	co, err = detectCheckout(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, checkout{Org: "apache", Repo: "ozone", ForkOrg: "elek", Branch: "HDDS-1234"}, co)
+}
+
+func TestCheckoutDefaults(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ogh-checkout")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	repository, err := git.PlainInit(dir, false)
+	assert.Nil(t, err)
+	_, err = repository.CreateRemote(&gitconfig.RemoteConfig{Name: "origin", URLs: []string{"git@github.com:elek/ozone.git"}})
+	assert.Nil(t, err)
+	assert.Nil(t, repository.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("HDDS-1234"))))
+
+	//the current branch is preferred to the branch of the project configuration
+	assert.Nil(t, ioutil.WriteFile(path.Join(dir, ".ogh.yaml"), []byte("branch: master\n"), 0600))
+	ref := checkoutDefaults(dir)
+	assert.Equal(t, Reference{Org: "elek", Repo: "ozone", Branch: "HDDS-1234"}, ref)
+
+	//the repository of the project configuration is preferred to the remotes
+	assert.Nil(t, ioutil.WriteFile(path.Join(dir, ".ogh.yaml"), []byte("repo: ratis\n"), 0600))
+	ref = checkoutDefaults(dir)
+	assert.Equal(t, Reference{Org: currentConfig.Org, Repo: currentConfig.Repo, Branch: "HDDS-1234"}, ref)
+
+	//outside of a checkout only the configuration is used
+	other, err := ioutil.TempDir("", "ogh-other")
+	assert.Nil(t, err)
+	defer os.RemoveAll(other)
+	assert.Equal(t, ParseReference(""), checkoutDefaults(other))
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+//content of the ogh configuration files
+type config struct {
+	//default repository and branch of the commands
+	Org    string `yaml:"org"`
+	Repo   string `yaml:"repo"`
+	Branch string `yaml:"branch"`
+	//name of the repository in the personal forks
+	ForkRepo string `yaml:"forkRepo"`
+
+	ApiUrl     string `yaml:"apiUrl"`
+	GraphqlUrl string `yaml:"graphqlUrl"`
+	WebUrl     string `yaml:"webUrl"`
+
+	Workflows workflowConfig `yaml:"workflows"`
+	Jira      jiraConfig     `yaml:"jira"`
+	Notify    notifyConfig   `yaml:"notify"`
+
+	//per repository (org/repo) settings
+	Repos map[string]repoConfig `yaml:"repos"`
+}
+
+type workflowConfig struct {
+	//workflow of the branch and pull request builds
+	Build string `yaml:"build"`
+	//not used any more (rerun handles all the workflows), accepted to keep the old configuration files valid
+	Pr string `yaml:"pr"`
+	//workflow of the push builds in the personal forks
+	Fork string `yaml:"fork"`
+}
+
+type jiraConfig struct {
+	Url string `yaml:"url"`
+	//jira project key (default: derived from the name of the github repository)
+	Project    string `yaml:"project"`
+	FixVersion string `yaml:"fixVersion"`
+}
+
+//hooks of `ogh notify` which are called when a watched build is finished
+type notifyConfig struct {
+	//shell command, executed with the JSON payload on the standard input
+	Command string `yaml:"command"`
+	//send desktop notification with notify-send
+	Desktop bool `yaml:"desktop"`
+	//url where the JSON payload is POST-ed
+	Webhook string `yaml:"webhook"`
+}
+
+type repoConfig struct {
+	Ready *readyPolicy `yaml:"ready"`
+}
+
+//defaults of Apache Ozone, used without any configuration file
+func defaultConfig() config {
+	return config{
+		Org:      "apache",
+		Repo:     "hadoop-ozone",
+		Branch:   "master",
+		ForkRepo: "ozone",
+		Workflows: workflowConfig{
+			Build: "post-commit.yml",
+			Fork:  "post-commit.yml",
+		},
+		Jira: jiraConfig{
+			Url:        "https://issues.apache.org/jira",
+			FixVersion: "1.1.0",
+		},
+		Repos: make(map[string]repoConfig),
+	}
+}
+
+//active configuration (defaults + user configuration + project configuration)
+var currentConfig = defaultConfig()
+
+//location of the user configuration file (OGH_CONFIG or ~/.config/ogh/config.yaml)
+func configFile() string {
+	file := os.Getenv("OGH_CONFIG")
+	if file == "" {
+		home, err := os.UserHomeDir()
+		if err == nil {
+			file = path.Join(home, ".config", "ogh", "config.yaml")
+		}
+	}
+	return file
+}
+
+//location of the project configuration file (.ogh.yaml in the root of the current git repository)
+func projectConfigFile() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	gitDir := findGitDir(wd)
+	if gitDir == "" {
+		return ""
+	}
+	return path.Join(filepath.Dir(gitDir), ".ogh.yaml")
+}
+
+//read the configuration file (missing file means empty configuration)
+func readConfig(file string) (config, error) {
+	conf := config{}
+	if file == "" {
+		return conf, nil
+	}
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return conf, nil
+	}
+	if err != nil {
+		return conf, err
+	}
+	err = yaml.UnmarshalStrict(data, &conf)
+	if err != nil {
+		return conf, errors.Wrap(err, "Couldn't parse the configuration file "+file)
+	}
+	return conf, nil
+}
+
+func override(value *string, newValue string) {
+	if newValue != "" {
+		*value = newValue
+	}
+}
+
+//returns with the base configuration where the defined values are overridden by the other configuration
+func mergeConfig(base config, other config) config {
+	result := base
+	override(&result.Org, other.Org)
+	override(&result.Repo, other.Repo)
+	override(&result.Branch, other.Branch)
+	override(&result.ForkRepo, other.ForkRepo)
+	override(&result.ApiUrl, other.ApiUrl)
+	override(&result.GraphqlUrl, other.GraphqlUrl)
+	override(&result.WebUrl, other.WebUrl)
+	override(&result.Workflows.Build, other.Workflows.Build)
+	override(&result.Workflows.Fork, other.Workflows.Fork)
+	override(&result.Jira.Url, other.Jira.Url)
+	override(&result.Jira.Project, other.Jira.Project)
+	override(&result.Jira.FixVersion, other.Jira.FixVersion)
+	override(&result.Notify.Command, other.Notify.Command)
+	override(&result.Notify.Webhook, other.Notify.Webhook)
+	result.Notify.Desktop = base.Notify.Desktop || other.Notify.Desktop
+
+	result.Repos = make(map[string]repoConfig)
+	for name, repo := range base.Repos {
+		result.Repos[name] = repo
+	}
+	for name, repo := range other.Repos {
+		result.Repos[name] = mergeRepoConfig(result.Repos[name], repo)
+	}
+	return result
+}
+
+//merge the repository specific configuration field by field
+func mergeRepoConfig(base repoConfig, other repoConfig) repoConfig {
+	result := base
+	if other.Ready != nil {
+		ready := mergeReadyPolicy(readyPolicy{}, *other.Ready)
+		if base.Ready != nil {
+			ready = mergeReadyPolicy(*base.Ready, *other.Ready)
+		}
+		result.Ready = &ready
+	}
+	return result
+}
+
+//returns with the base policy where the defined values are overridden by the other policy (lists can be emptied with [])
+func mergeReadyPolicy(base readyPolicy, other readyPolicy) readyPolicy {
+	result := base
+	if other.AllowDraft != nil {
+		result.AllowDraft = other.AllowDraft
+	}
+	if other.BlockConflicting != nil {
+		result.BlockConflicting = other.BlockConflicting
+	}
+	if other.RequiredChecks != nil {
+		result.RequiredChecks = other.RequiredChecks
+	}
+	if other.RequiredApprovals != nil {
+		result.RequiredApprovals = other.RequiredApprovals
+	}
+	if other.IgnoredLabels != nil {
+		result.IgnoredLabels = other.IgnoredLabels
+	}
+	if other.IgnoredUsers != nil {
+		result.IgnoredUsers = other.IgnoredUsers
+	}
+	return result
+}
+
+//read the user configuration and the project configuration on top of the defaults
+func loadConfig() (config, error) {
+	userConfig, err := readConfig(configFile())
+	if err != nil {
+		return config{}, err
+	}
+	projectConfig, err := readConfig(projectConfigFile())
+	if err != nil {
+		return config{}, err
+	}
+	conf := defaultConfig()
+	for _, layer := range []config{userConfig, projectConfig} {
+		conf = mergeConfig(conf, layer)
+	}
+	return conf, nil
+}
+
+//configuration of one repository
+func (conf config) repo(org string, repo string) repoConfig {
+	return conf.Repos[org+"/"+repo]
+}
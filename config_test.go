@@ -0,0 +1,77 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ogh-config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	userConfig := path.Join(dir, "config.yaml")
+	assert.Nil(t, ioutil.WriteFile(userConfig, []byte(`
+jira:
+  fixVersion: 1.2.0
+repos:
+  apache/ozone:
+    ready:
+      requiredApprovals: 1
+      blockConflicting: true
+`), 0600))
+
+	project := path.Join(dir, "ratis")
+	assert.Nil(t, os.MkdirAll(path.Join(project, ".git"), 0700))
+	assert.Nil(t, ioutil.WriteFile(path.Join(project, ".ogh.yaml"), []byte(`
+repo: ratis
+workflows:
+  build: ci.yaml
+jira:
+  project: RATIS
+repos:
+  apache/ozone:
+    ready:
+      requiredChecks: [basic]
+      requiredApprovals: 0
+      blockConflicting: false
+`), 0600))
+	assert.Nil(t, os.MkdirAll(path.Join(project, "src"), 0700))
+
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	defer os.Chdir(wd)
+	assert.Nil(t, os.Chdir(path.Join(project, "src")))
+	os.Setenv("OGH_CONFIG", userConfig)
+	defer os.Unsetenv("OGH_CONFIG")
+
+	conf, err := loadConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, "apache", conf.Org)
+	assert.Equal(t, "ratis", conf.Repo)
+	assert.Equal(t, "ci.yaml", conf.Workflows.Build)
+	assert.Equal(t, "post-commit.yml", conf.Workflows.Fork)
+	assert.Equal(t, "1.2.0", conf.Jira.FixVersion)
+	assert.Equal(t, "RATIS", conf.Jira.Project)
+	assert.Equal(t, "https://issues.apache.org/jira", conf.Jira.Url)
+	assert.Equal(t, 0, conf.repo("apache", "ozone").Ready.requiredApprovals())
+	//the project configuration can switch off the settings of the user configuration
+	assert.False(t, conf.repo("apache", "ozone").Ready.blockConflicting())
+	assert.NotNil(t, conf.repo("apache", "ozone").Ready.BlockConflicting)
+	assert.Equal(t, []string{"basic"}, conf.repo("apache", "ozone").Ready.RequiredChecks)
+}
+
+func TestReadConfigUnknownField(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ogh-config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	file := path.Join(dir, "config.yaml")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("orgg: apache\n"), 0600))
+	_, err = readConfig(file)
+	assert.NotNil(t, err)
+}
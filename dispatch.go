@@ -0,0 +1,55 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"path"
+	"strings"
+)
+
+//parse the workflow inputs from key=value pairs
+func parseInputs(fields []string) (map[string]string, error) {
+	inputs := make(map[string]string)
+	for _, field := range fields {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.New("Invalid workflow input (use key=value): " + field)
+		}
+		inputs[parts[0]] = parts[1]
+	}
+	return inputs, nil
+}
+
+//start a workflow_dispatch run of the workflow on the branch of the reference
+func dispatch(ref Reference, workflow string, inputs map[string]string) error {
+	if ref.Branch == "" {
+		return errors.New("Branch of the workflow run is not defined (use --ref)")
+	}
+	workflowId, err := resolveWorkflowId(ref.Org, ref.Repo, workflow)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]interface{}{
+		"ref":    ref.Branch,
+		"inputs": inputs,
+	})
+	if err != nil {
+		return err
+	}
+	resp, err := callGithubApiV3WithBody("POST", apiUrl("/repos/")+ref.Org+"/"+ref.Repo+"/actions/workflows/"+workflowId+"/dispatches", body)
+	if err != nil {
+		return err
+	}
+	err = resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	runsUrl := webUrl("/" + ref.Org + "/" + ref.Repo + "/actions")
+	if details, err := GetWorkflow(ref.Org, ref.Repo, workflowId); err == nil && details.Path != "" {
+		runsUrl += "/workflows/" + path.Base(details.Path)
+	}
+	fmt.Printf("Workflow %s is dispatched on %s: %s\n", workflow, ref.Branch, runsUrl)
+	return nil
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDispatch(t *testing.T) {
+	request := make(map[string]interface{})
+	cleanup := withFakeGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/apache/ozone/actions/workflows":
+			_, _ = w.Write([]byte(`{"total_count": 1, "workflows": [{"id": 8247, "name": "build-branch", "path": ".github/workflows/post-commit.yml"}]}`))
+		case "/repos/apache/ozone/actions/workflows/8247/dispatches":
+			assert.Equal(t, "POST", r.Method)
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer cleanup()
+
+	inputs, err := parseInputs([]string{"ratis=2.0.0", "args=-Dskip=true"})
+	assert.Nil(t, err)
+
+	err = dispatch(Reference{Org: "apache", Repo: "ozone", Branch: "HDDS-1"}, "post-commit.yml", inputs)
+	assert.Nil(t, err)
+	assert.Equal(t, "HDDS-1", request["ref"])
+	assert.Equal(t, map[string]interface{}{"ratis": "2.0.0", "args": "-Dskip=true"}, request["inputs"])
+
+	_, err = parseInputs([]string{"ratis"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"archive/zip"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//number of the artifacts which are downloaded at the same time
+var parallelDownloads = 4
+
+//number of attempts to download one artifact (each attempt continues the partial download)
+var downloadAttempts = 3
+
+//one artifact archive to download
+type artifactDownload struct {
+	Name string
+	Url  string
+	//expected size of the archive (0 if unknown)
+	Size int64
+	//destination zip file
+	Path string
+}
+
+//download all the artifacts with a bounded worker pool
+func downloadAll(downloads []artifactDownload, workers int, progress *downloadProgress) error {
+	if workers < 1 {
+		workers = 1
+	}
+	queue := make(chan artifactDownload)
+	failures := make(chan string, len(downloads))
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for download := range queue {
+				err := downloadResumable(download, progress)
+				progress.finish(download.Name, err)
+				if err != nil {
+					failures <- download.Name + ": " + err.Error()
+				}
+			}
+		}()
+	}
+	for _, download := range downloads {
+		progress.register(download.Name, download.Size)
+	}
+	for _, download := range downloads {
+		queue <- download
+	}
+	close(queue)
+	wg.Wait()
+	close(failures)
+	progress.stop()
+
+	messages := make([]string, 0)
+	for failure := range failures {
+		messages = append(messages, failure)
+	}
+	if len(messages) > 0 {
+		return errors.New("Some of the artifacts couldn't be downloaded: " + strings.Join(messages, "; "))
+	}
+	return nil
+}
+
+func fileSize(file string) int64 {
+	info, err := os.Stat(file)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+//download the archive to a temporary (.part) file which is renamed only after the size is verified.
+//Interrupted downloads are continued with HTTP Range requests.
+func downloadResumable(download artifactDownload, progress *downloadProgress) error {
+	if _, err := os.Stat(download.Path); err == nil {
+		//zip files are renamed only after the verification, but older versions saved partial downloads
+		if archive, err := zip.OpenReader(download.Path); err == nil {
+			archive.Close()
+			progress.set(download.Name, fileSize(download.Path), fileSize(download.Path))
+			return nil
+		}
+		log.Debug().Msgf("%s is not a valid zip file, downloading it again", download.Path)
+		if err := os.Remove(download.Path); err != nil {
+			return err
+		}
+	}
+
+	partPath := download.Path + ".part"
+	var err error
+	for attempt := 1; attempt <= downloadAttempts; attempt++ {
+		download.Size, err = downloadPart(download, partPath, progress)
+		if err == nil {
+			_ = os.Remove(partPath + ".size")
+			return os.Rename(partPath, download.Path)
+		}
+		log.Debug().Msgf("Download of %s is failed (attempt %d): %s", download.Name, attempt, err.Error())
+	}
+	return err
+}
+
+//size of the archive reported by the server, saved next to the .part file (0 if unknown)
+func readRecordedSize(sizePath string) int64 {
+	data, err := ioutil.ReadFile(sizePath)
+	if err != nil {
+		return 0
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+func recordSize(sizePath string, size int64) error {
+	return ioutil.WriteFile(sizePath, []byte(strconv.FormatInt(size, 10)), 0644)
+}
+
+//continue the download of the .part file and verify the size of the result. Returns with the expected size (which can be updated by the server response).
+//The size reported by the server is recorded, as the archive size can be different from the artifact size (in case of older artifacts).
+func downloadPart(download artifactDownload, partPath string, progress *downloadProgress) (int64, error) {
+	sizePath := partPath + ".size"
+	offset := fileSize(partPath)
+	expected := download.Size
+	recorded := readRecordedSize(sizePath)
+	if recorded > 0 {
+		expected = recorded
+	}
+	if recorded > 0 && offset > recorded {
+		if err := os.Remove(partPath); err != nil {
+			return expected, err
+		}
+		offset = 0
+	}
+
+	if recorded == 0 || offset < recorded {
+		headers := make(map[string]string)
+		if offset > 0 {
+			headers["Range"] = "bytes=" + strconv.FormatInt(offset, 10) + "-"
+		}
+		resp, err := requestGithubApiV3("GET", download.Url, nil, headers)
+		if err != nil {
+			return expected, err
+		}
+		defer resp.Body.Close()
+
+		switch {
+		case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+			//the .part file is already complete (or it's bigger than the archive)
+			total := contentRangeTotal(resp.Header.Get("Content-Range"))
+			if total == 0 || total != offset {
+				_ = os.Remove(partPath)
+				return expected, errors.New("Partial download of " + download.Name + " is invalid (" + resp.Status + ")")
+			}
+			expected = total
+			if err := recordSize(sizePath, total); err != nil {
+				return expected, err
+			}
+			progress.set(download.Name, offset, expected)
+		case resp.StatusCode > 299:
+			return expected, errors.New("GET url is failed (" + resp.Status + "): " + download.Url)
+		default:
+			flags := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+			if resp.StatusCode != http.StatusPartialContent {
+				//the server sent the full content
+				flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+				offset = 0
+			}
+			if total := responseTotalSize(resp); total > 0 {
+				if total != expected {
+					log.Debug().Msgf("Size of %s is %d instead of %d", download.Name, total, expected)
+				}
+				expected = total
+				if err := recordSize(sizePath, total); err != nil {
+					return expected, err
+				}
+			}
+			progress.set(download.Name, offset, expected)
+
+			partFile, err := os.OpenFile(partPath, flags, 0644)
+			if err != nil {
+				return expected, err
+			}
+			_, err = io.Copy(partFile, &progressReader{reader: resp.Body, name: download.Name, progress: progress})
+			closeErr := partFile.Close()
+			if err != nil {
+				return expected, err
+			}
+			if closeErr != nil {
+				return expected, closeErr
+			}
+		}
+	}
+
+	if size := fileSize(partPath); expected > 0 && size != expected {
+		return expected, errors.New("Downloaded size is " + strconv.FormatInt(size, 10) + " instead of " + strconv.FormatInt(expected, 10))
+	}
+	return expected, nil
+}
+
+//total size from a Content-Range header (bytes 0-99/1000 or bytes */1000), 0 if unknown
+func contentRangeTotal(contentRange string) int64 {
+	if index := strings.LastIndex(contentRange, "/"); index >= 0 {
+		if total, err := strconv.ParseInt(contentRange[index+1:], 10, 64); err == nil {
+			return total
+		}
+	}
+	return 0
+}
+
+//full size of the resource from the Content-Range or Content-Length headers (0 if unknown)
+func responseTotalSize(resp *http.Response) int64 {
+	if resp.StatusCode == http.StatusPartialContent {
+		return contentRangeTotal(resp.Header.Get("Content-Range"))
+	}
+	if resp.ContentLength > 0 {
+		return resp.ContentLength
+	}
+	return 0
+}
+
+//reader which reports the number of the read bytes to the progress display
+type progressReader struct {
+	reader   io.Reader
+	name     string
+	progress *downloadProgress
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.progress.add(r.name, int64(n))
+	return n, err
+}
+
+//state of one download in the progress display
+type downloadState struct {
+	done   int64
+	total  int64
+	status string
+}
+
+//progress display of the parallel downloads. On terminal the lines are redrawn periodically,
+//otherwise one line is printed when a download is finished.
+type downloadProgress struct {
+	mutex       sync.Mutex
+	out         io.Writer
+	interactive bool
+	names       []string
+	states      map[string]*downloadState
+	drawn       int
+	ticker      *time.Ticker
+	stopped     chan bool
+}
+
+func newDownloadProgress(out *os.File) *downloadProgress {
+	interactive := false
+	if info, err := out.Stat(); err == nil {
+		interactive = info.Mode()&os.ModeCharDevice != 0
+	}
+	progress := &downloadProgress{
+		out:         out,
+		interactive: interactive,
+		states:      make(map[string]*downloadState),
+	}
+	if interactive {
+		progress.ticker = time.NewTicker(500 * time.Millisecond)
+		progress.stopped = make(chan bool)
+		go func() {
+			for {
+				select {
+				case <-progress.ticker.C:
+					progress.render()
+				case <-progress.stopped:
+					return
+				}
+			}
+		}()
+	}
+	return progress
+}
+
+func (p *downloadProgress) register(name string, total int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.names = append(p.names, name)
+	p.states[name] = &downloadState{total: total}
+}
+
+func (p *downloadProgress) add(name string, n int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if state, found := p.states[name]; found {
+		state.done += n
+	}
+}
+
+func (p *downloadProgress) set(name string, done int64, total int64) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if state, found := p.states[name]; found {
+		state.done = done
+		state.total = total
+	}
+}
+
+func (p *downloadProgress) finish(name string, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	state, found := p.states[name]
+	if !found {
+		return
+	}
+	state.status = "done"
+	if err != nil {
+		state.status = "failed"
+	}
+	if !p.interactive {
+		fmt.Fprintln(p.out, formatDownloadState(name, state))
+	}
+}
+
+func formatDownloadState(name string, state *downloadState) string {
+	line := fmt.Sprintf("%-40s %10s", limit(name, 40), humanSize(state.done))
+	if state.total > 0 {
+		line += fmt.Sprintf(" / %-10s %3d%%", humanSize(state.total), state.done*100/state.total)
+	}
+	if state.status != "" {
+		line += " " + state.status
+	}
+	return line
+}
+
+//redraw the progress lines (moving the cursor back to the first line)
+func (p *downloadProgress) render() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.drawn > 0 {
+		fmt.Fprintf(p.out, "\033[%dA", p.drawn)
+	}
+	for _, name := range p.names {
+		fmt.Fprintf(p.out, "\033[2K%s\n", formatDownloadState(name, p.states[name]))
+	}
+	p.drawn = len(p.names)
+}
+
+//stop the periodic redraw and print the final state
+func (p *downloadProgress) stop() {
+	if !p.interactive {
+		return
+	}
+	p.ticker.Stop()
+	p.stopped <- true
+	p.render()
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDownloadResume(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 100))
+	ranges := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		if len(ranges) == 1 {
+			//connection is closed in the middle of the download
+			w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			_, _ = w.Write(content[:300])
+			return
+		}
+		http.ServeContent(w, r, "it-ozone.zip", time.Now(), bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "ogh-download")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	progress := &downloadProgress{out: &bytes.Buffer{}, states: make(map[string]*downloadState)}
+	download := artifactDownload{Name: "it-ozone", Url: server.URL, Size: int64(len(content)), Path: path.Join(dir, "it-ozone.zip")}
+
+	err = downloadAll([]artifactDownload{download}, 2, progress)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"", "bytes=300-"}, ranges)
+	downloaded, err := ioutil.ReadFile(download.Path)
+	assert.Nil(t, err)
+	assert.Equal(t, content, downloaded)
+	_, err = os.Stat(download.Path + ".part")
+	assert.True(t, os.IsNotExist(err))
+	assert.Contains(t, progress.out.(*bytes.Buffer).String(), "100% done")
+}
+
+func TestDownloadSizeMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "500")
+		_, _ = w.Write([]byte(strings.Repeat("x", 200)))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "ogh-download")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	progress := &downloadProgress{out: &bytes.Buffer{}, states: make(map[string]*downloadState)}
+	download := artifactDownload{Name: "acceptance-secure", Url: server.URL, Size: 500, Path: path.Join(dir, "acceptance-secure.zip")}
+
+	err = downloadAll([]artifactDownload{download}, 1, progress)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "acceptance-secure")
+
+	//partial download is never saved with the final name
+	_, err = os.Stat(download.Path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestDownloadResumeWithDifferentArchiveSize(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 100))
+	ranges := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		http.ServeContent(w, r, "it-ozone.zip", time.Now(), bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "ogh-download")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	//size_in_bytes of the artifact is smaller than the zip archive
+	incomplete := artifactDownload{Name: "incomplete", Url: server.URL, Size: 800, Path: path.Join(dir, "incomplete.zip")}
+	assert.Nil(t, ioutil.WriteFile(incomplete.Path+".part", content[:900], 0644))
+	complete := artifactDownload{Name: "complete", Url: server.URL, Size: 0, Path: path.Join(dir, "complete.zip")}
+	assert.Nil(t, ioutil.WriteFile(complete.Path+".part", content, 0644))
+
+	progress := &downloadProgress{out: &bytes.Buffer{}, states: make(map[string]*downloadState)}
+	err = downloadAll([]artifactDownload{incomplete, complete}, 1, progress)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"bytes=900-", "bytes=1000-"}, ranges)
+	for _, download := range []artifactDownload{incomplete, complete} {
+		downloaded, err := ioutil.ReadFile(download.Path)
+		assert.Nil(t, err)
+		assert.Equal(t, content, downloaded)
+		_, err = os.Stat(download.Path + ".part.size")
+		assert.True(t, os.IsNotExist(err))
+	}
+}
@@ -7,26 +7,91 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"gopkg.in/yaml.v2"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"os/user"
 	"path"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
+//base url of the Github REST API (can be changed for Github Enterprise or for local test servers)
+var githubApiUrl = "https://api.github.com"
 
-func callGithubApiV3(method string, url string) (*http.Response, error) {
-	client := &http.Client{}
-	log.Debug().Msgf("%s url from GITHUB api: %s ", method, url)
+//url of the Github GraphQL API, derived from githubApiUrl if empty
+var githubGraphqlUrl = ""
 
-	req, err := http.NewRequest(method, url, nil)
-	req.Header.Add("Authorization", "token "+GetToken())
-	req.Header.Add("Accept", "application/vnd.github.v3+json")
-	if err != nil {
-		return nil, err
+//base url of the Github web pages (pull requests, workflow runs), derived from githubApiUrl if empty
+var githubWebUrl = ""
+
+//absolute url of a REST API path (eg. /repos/apache/ozone)
+func apiUrl(path string) string {
+	return strings.TrimSuffix(githubApiUrl, "/") + path
+}
+
+//host of the REST API, cached entries of different Github instances are separated by the host
+func apiHost() string {
+	parsed, err := url.Parse(githubApiUrl)
+	if err != nil || parsed.Host == "" {
+		return "github"
 	}
-	resp, err := client.Do(req)
+	return parsed.Host
+}
+
+//absolute url of a Github web page (eg. /apache/ozone/pull/123)
+func webUrl(path string) string {
+	base := githubWebUrl
+	if base == "" {
+		base = webBaseUrl(githubApiUrl)
+	}
+	return strings.TrimSuffix(base, "/") + path
+}
+
+//web url of a Github instance based on the REST API url (https://api.github.com or https://HOST/api/v3)
+func webBaseUrl(api string) string {
+	parsed, err := url.Parse(api)
+	if err != nil || parsed.Host == "" {
+		return "https://github.com"
+	}
+	return parsed.Scheme + "://" + strings.TrimPrefix(parsed.Host, "api.")
+}
+
+//host of the web pages, used to find the token of the Github instance in the hub/gh configuration
+func webHost() string {
+	parsed, err := url.Parse(webUrl(""))
+	if err != nil || parsed.Host == "" {
+		return "github.com"
+	}
+	return parsed.Host
+}
+
+func graphqlUrl() string {
+	if githubGraphqlUrl != "" {
+		return githubGraphqlUrl
+	}
+	base := strings.TrimSuffix(githubApiUrl, "/")
+	//Github Enterprise serves REST from /api/v3 and GraphQL from /api/graphql
+	if strings.HasSuffix(base, "/api/v3") {
+		return strings.TrimSuffix(base, "/v3") + "/graphql"
+	}
+	return base + "/graphql"
+}
+
+func callGithubApiV3(method string, url string) (*http.Response, error) {
+	return callGithubApiV3WithBody(method, url, nil)
+}
+
+func callGithubApiV3WithBody(method string, url string, body []byte) (*http.Response, error) {
+	return callGithubApiV3WithHeaders(method, url, body, nil)
+}
+
+//call the Github API with additional request headers (like Range)
+func callGithubApiV3WithHeaders(method string, url string, body []byte, headers map[string]string) (*http.Response, error) {
+	resp, err := requestGithubApiV3(method, url, body, headers)
 	if err != nil {
 		return nil, err
 	}
@@ -43,74 +108,377 @@ func callGithubApiV3(method string, url string) (*http.Response, error) {
 	return resp, nil
 }
 
+//send the request and return with the response, regardless of the status code
+func requestGithubApiV3(method string, url string, body []byte, headers map[string]string) (*http.Response, error) {
+	client := githubStreamClient()
+	log.Debug().Msgf("%s url from GITHUB api: %s ", method, url)
+
+	var requestBody io.Reader
+	if len(body) > 0 {
+		requestBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, url, requestBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Authorization", "token "+GetToken())
+	req.Header.Add("Accept", "application/vnd.github.v3+json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return client.Do(req)
+}
+
 func readGithubApiV3(url string) ([]byte, error) {
-	client := &http.Client{}
+	response, _, err := readGithubApiV3Conditional(url, cacheValidators{})
+	return response.Body, err
+}
+
+//GET request which is sent with If-None-Match/If-Modified-Since headers if validators are available
+func readGithubApiV3Conditional(url string, validators cacheValidators) (conditionalResponse, http.Header, error) {
+	client := githubClient()
 	log.Debug().Msgf("Reading url from GITHUB api: %s ", url)
 
+	response := conditionalResponse{}
 	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return response, nil, err
+	}
 	req.Header.Add("Authorization", "token "+GetToken())
 	req.Header.Add("Accept", "application/vnd.github.antiope-preview+json")
-	if err != nil {
-		return nil, err
+	if validators.ETag != "" {
+		req.Header.Add("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Add("If-Modified-Since", validators.LastModified)
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return response, nil, err
 	}
 	defer resp.Body.Close()
+	response.Url = url
+	response.Status = resp.StatusCode
+	if resp.StatusCode == http.StatusNotModified {
+		response.NotModified = true
+		response.Validators = validators
+		return response, resp.Header, nil
+	}
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return response, nil, err
 	}
 	if resp.StatusCode > 299 {
 		log.Error().Msgf(string(body))
-		return nil, errors.New("Reading url is failed (" + resp.Status + "): " + url)
+		return response, nil, errors.New("Reading url is failed (" + resp.Status + "): " + url)
+	}
+	response.Body = body
+	response.Validators = cacheValidators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
 	}
-	return body, nil
+	return response, resp.Header, nil
 }
 
-func readPrWithGraphql(ref Reference) ([]byte, error) {
-	client := &http.Client{}
+//conditional getter of a single REST API resource
+func restGetter(url string) conditionalGetter {
+	return func(validators cacheValidators) (conditionalResponse, error) {
+		response, _, err := readGithubApiV3Conditional(url, validators)
+		return response, err
+	}
+}
 
-	f, err := pkger.Open("/pr.graphql")
-	if err != nil {
-		return nil, err
+//conditional getter of a paginated REST API list
+func restListGetter(url string, listField string) conditionalGetter {
+	return func(validators cacheValidators) (conditionalResponse, error) {
+		return readAllPagesOfGithubApiV3Conditional(url, listField, validators)
 	}
+}
 
-	graphql, err := ioutil.ReadAll(f)
-	if err != nil {
-		return nil, err
+//maximum number of pages to read from a list endpoint
+var maxPages = 10
+
+var linkRE = regexp.MustCompile(`<([^>]+)>\s*;\s*rel="([^"]+)"`)
+
+//returns the url of the next page based on the Link header (or empty string)
+func nextPageUrl(header http.Header) string {
+	for _, link := range header["Link"] {
+		for _, match := range linkRE.FindAllStringSubmatch(link, -1) {
+			if match[2] == "next" {
+				return match[1]
+			}
+		}
 	}
+	return ""
+}
+
+//read all the pages of a list endpoint and merge them to one response.
+//listField is the field of the response object which contains the items (empty if the response is a json array)
+func readAllPagesOfGithubApiV3(url string, listField string) ([]byte, error) {
+	response, err := readAllPagesOfGithubApiV3Conditional(url, listField, cacheValidators{})
+	return response.Body, err
+}
+
+//read all the pages of a list endpoint. The first page is requested conditionally and its validators are used for the
+//whole list: if the first page is not modified, the cached list is used, otherwise all the pages are downloaded again.
+//(Github lists are ordered by the newest items, changes are usually visible on the first page)
+func readAllPagesOfGithubApiV3Conditional(url string, listField string, validators cacheValidators) (conditionalResponse, error) {
+	var first map[string]json.RawMessage
+	items := make([]json.RawMessage, 0)
+	result := conditionalResponse{}
+	for page := 0; url != "" && page < maxPages; page++ {
+		response, header, err := readGithubApiV3Conditional(url, validators)
+		if err != nil {
+			return result, err
+		}
+		if response.NotModified {
+			return response, nil
+		}
+		if page == 0 {
+			result.Validators = response.Validators
+		}
+		validators = cacheValidators{}
 
-	defer f.Close()
+		pageItems := make([]json.RawMessage, 0)
+		if listField == "" {
+			err = json.Unmarshal(response.Body, &pageItems)
+			if err != nil {
+				return result, errors.Wrap(err, "Response is not a json array: "+url)
+			}
+		} else {
+			object := make(map[string]json.RawMessage)
+			err = json.Unmarshal(response.Body, &object)
+			if err != nil {
+				return result, errors.Wrap(err, "Response is not a json object: "+url)
+			}
+			if list, found := object[listField]; found {
+				err = json.Unmarshal(list, &pageItems)
+				if err != nil {
+					return result, errors.Wrap(err, "Field "+listField+" is not a json array: "+url)
+				}
+			}
+			if first == nil {
+				first = object
+			}
+		}
+		items = append(items, pageItems...)
+		url = nextPageUrl(header)
+	}
+	if url != "" {
+		log.Warn().Msgf("Result is truncated after %d pages, next page would be %s", maxPages, url)
+		result.Validators = cacheValidators{}
+	}
 
-	queryPayload := make(map[string]string)
-	queryString := string(graphql)
-	queryString = strings.Replace(queryString,
-		"owner: \"apache\", name: \"ozone\"",
-		"owner: \""+ref.Org+"\", name: \""+ref.Repo+"\"", 1)
-	queryPayload["query"] = queryString
-	query, err := json.Marshal(queryPayload)
+	var err error
+	if listField == "" {
+		result.Body, err = json.Marshal(items)
+		return result, err
+	}
+	if first == nil {
+		first = make(map[string]json.RawMessage)
+	}
+	mergedItems, err := json.Marshal(items)
 	if err != nil {
-		return nil, err
+		return result, err
 	}
+	first[listField] = mergedItems
+	result.Body, err = json.Marshal(first)
+	return result, err
+}
 
-	req, err := http.NewRequest("POST", "https://api.github.com/graphql", bytes.NewReader(query))
-	req.Header.Add("Authorization", "token "+GetToken())
-	req.Header.Add("Accept", "application/vnd.github.antiope-preview+json")
+//server side filters of the pull request query
+type PrQuery struct {
+	States     []string
+	BaseBranch string
+	//only the pull requests of the author (found with the search API)
+	Author string
+}
+
+//unique suffix of the cache key for the query
+func (query PrQuery) key() string {
+	key := strings.ToLower(strings.Join(query.States, "-"))
+	if query.BaseBranch != "" {
+		key += "-" + query.BaseBranch
+	}
+	if query.Author != "" {
+		key += "-author-" + strings.ToLower(query.Author)
+	}
+	return key
+}
+
+//search qualifiers of the pull request states
+var prStateQualifiers = map[string]string{
+	"OPEN":   "is:open",
+	"CLOSED": "is:closed is:unmerged",
+	"MERGED": "is:merged",
+}
+
+//search expression of the query (the search API supports only one state)
+func (query PrQuery) searchString(ref Reference) (string, error) {
+	search := "repo:" + ref.Org + "/" + ref.Repo + " is:pr author:" + query.Author + " sort:updated-desc"
+	if len(query.States) > 1 {
+		return "", errors.New("Pull requests of an author can be searched only with one state")
+	}
+	for _, state := range query.States {
+		qualifier, found := prStateQualifiers[state]
+		if !found {
+			return "", errors.New("Unknown pull request state: " + state)
+		}
+		search += " " + qualifier
+	}
+	if query.BaseBranch != "" {
+		search += " base:" + query.BaseBranch
+	}
+	return search, nil
+}
+
+//read the query and append the fragment of the pull request fields to it
+func withPrFields(queryString string, err error) (string, error) {
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	resp, err := client.Do(req)
+	fields, err := readGraphqlFile(pkger.Open("/pr-fields.graphql"))
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	defer resp.Body.Close()
-	body, err := ioutil.ReadAll(resp.Body)
+	return queryString + "\n" + fields, nil
+}
+
+//read one page of the matching pull requests
+func readPrPage(ref Reference, query PrQuery, cursor string) (PullRequestsResult, error) {
+	result := PullRequestsResult{}
+	variables := map[string]interface{}{
+		"cursor": nil,
+	}
+	if cursor != "" {
+		variables["cursor"] = cursor
+	}
+	if query.Author == "" {
+		queryString, err := withPrFields(readGraphqlFile(pkger.Open("/pr.graphql")))
+		if err != nil {
+			return result, err
+		}
+		variables["owner"] = ref.Org
+		variables["name"] = ref.Repo
+		variables["states"] = query.States
+		variables["baseRefName"] = nil
+		if query.BaseBranch != "" {
+			variables["baseRefName"] = query.BaseBranch
+		}
+		err = queryGraphql(queryString, variables, &result)
+		return result, err
+	}
+
+	queryString, err := withPrFields(readGraphqlFile(pkger.Open("/pr-search.graphql")))
 	if err != nil {
-		return nil, err
+		return result, err
 	}
-	return body, nil
+	variables["search"], err = query.searchString(ref)
+	if err != nil {
+		return result, err
+	}
+	searchResult := PullRequestSearchResult{}
+	err = queryGraphql(queryString, variables, &searchResult)
+	result.Data.Repository.PullRequests = searchResult.Data.Search
+	return result, err
+}
+
+//read all the matching pull requests (all pages) and returns with a merged PullRequestsResult json
+func readPrWithGraphql(ref Reference, query PrQuery) ([]byte, error) {
+	result := PullRequestsResult{}
+	cursor := ""
+	for page := 0; page < maxPages; page++ {
+		pageResult, err := readPrPage(ref, query, cursor)
+		if err != nil {
+			return nil, errors.Wrap(err, "Couldn't read the pull requests of "+ref.Org+"/"+ref.Repo)
+		}
+		pullRequests := &result.Data.Repository.PullRequests
+		pullRequests.Edges = append(pullRequests.Edges, pageResult.Data.Repository.PullRequests.Edges...)
+		pullRequests.PageInfo = pageResult.Data.Repository.PullRequests.PageInfo
+		if !pullRequests.PageInfo.HasNextPage {
+			break
+		}
+		cursor = pullRequests.PageInfo.EndCursor
+	}
+	if result.Data.Repository.PullRequests.PageInfo.HasNextPage {
+		log.Warn().Msgf("Pull request list is truncated after %d pages", maxPages)
+	}
+
+	for i := range result.Data.Repository.PullRequests.Edges {
+		err := readRemainingConnections(ref, &result.Data.Repository.PullRequests.Edges[i].Node)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(result)
+}
+
+//add cursor variables for a nested connection, returns true if the connection has more pages
+func pageVariables(variables map[string]interface{}, name string, pageInfo PageInfo) bool {
+	variables[name] = pageInfo.HasNextPage
+	variables[name+"Cursor"] = pageInfo.EndCursor
+	return pageInfo.HasNextPage
+}
+
+//fetch the remaining pages of the nested connections (reviews, comments, labels, ...) which didn't fit to the first page
+func readRemainingConnections(ref Reference, pr *GraphqlPullRequest) error {
+	queryString := ""
+	for page := 0; page < maxPages; page++ {
+		variables := map[string]interface{}{
+			"owner":  ref.Org,
+			"name":   ref.Repo,
+			"number": pr.Number,
+		}
+		checkSuite := pr.lastCheckSuite()
+		checkRunsPageInfo := PageInfo{}
+		if checkSuite != nil {
+			checkRunsPageInfo = checkSuite.CheckRuns.PageInfo
+		}
+		more := pageVariables(variables, "reviews", pr.Reviews.PageInfo)
+		more = pageVariables(variables, "reviewRequests", pr.ReviewRequests.PageInfo) || more
+		more = pageVariables(variables, "comments", pr.Comments.PageInfo) || more
+		more = pageVariables(variables, "participants", pr.Participants.PageInfo) || more
+		more = pageVariables(variables, "checkRuns", checkRunsPageInfo) || more
+		more = pageVariables(variables, "labels", pr.Labels.PageInfo) || more
+		if !more {
+			return nil
+		}
+
+		if queryString == "" {
+			var err error
+			queryString, err = readGraphqlFile(pkger.Open("/pr-connections.graphql"))
+			if err != nil {
+				return err
+			}
+		}
+		pageResult := PullRequestResult{}
+		err := queryGraphql(queryString, variables, &pageResult)
+		if err != nil {
+			return errors.Wrap(err, "Couldn't read the details of pull request "+strconv.Itoa(pr.Number))
+		}
+		next := pageResult.Data.Repository.PullRequest
+
+		pr.Reviews.Nodes = append(pr.Reviews.Nodes, next.Reviews.Nodes...)
+		pr.Reviews.PageInfo = next.Reviews.PageInfo
+		pr.ReviewRequests.Edges = append(pr.ReviewRequests.Edges, next.ReviewRequests.Edges...)
+		pr.ReviewRequests.PageInfo = next.ReviewRequests.PageInfo
+		pr.Comments.Nodes = append(pr.Comments.Nodes, next.Comments.Nodes...)
+		pr.Comments.PageInfo = next.Comments.PageInfo
+		pr.Participants.Edges = append(pr.Participants.Edges, next.Participants.Edges...)
+		pr.Participants.PageInfo = next.Participants.PageInfo
+		pr.Labels.Nodes = append(pr.Labels.Nodes, next.Labels.Nodes...)
+		pr.Labels.PageInfo = next.Labels.PageInfo
+		if checkSuite != nil {
+			checkRuns := &checkSuite.CheckRuns
+			checkRuns.PageInfo = PageInfo{}
+			if nextSuite := next.lastCheckSuite(); nextSuite != nil {
+				checkRuns.Edges = append(checkRuns.Edges, nextSuite.CheckRuns.Edges...)
+				checkRuns.PageInfo = nextSuite.CheckRuns.PageInfo
+			}
+		}
+	}
+	log.Warn().Msgf("Details of pull request %d are truncated after %d pages", pr.Number, maxPages)
+	return nil
 }
 
 func GetToken() string {
@@ -125,6 +493,25 @@ func GetToken() string {
 	return getTokenFromGhConfig()
 }
 
+//per host user entries of the hub/gh configuration files
+type hostConfig map[string][]struct {
+	User       string `yaml:"user"`
+	OauthToken string `yaml:"oauth_token"`
+}
+
+func tokenFromHostConfig(data []byte, host string) string {
+	hosts := hostConfig{}
+	err := yaml.Unmarshal(data, &hosts)
+	if err != nil {
+		return ""
+	}
+	users := hosts[host]
+	if len(users) > 0 {
+		return users[0].OauthToken
+	}
+	return ""
+}
+
 func getTokenFromHubConfig() string {
 	usr, err := user.Current()
 	if err != nil {
@@ -139,16 +526,7 @@ func getTokenFromHubConfig() string {
 		return ""
 	}
 
-	hubConfig := make(map[string]interface{})
-	err = yaml.Unmarshal(data, &hubConfig)
-	if err != nil {
-		return ""
-	}
-	users := l(m(hubConfig, "github.com"))
-	if len(users) > 0 {
-		return m(users[0], "oauth_token").(string)
-	}
-	return ""
+	return tokenFromHostConfig(data, webHost())
 
 }
 
@@ -166,15 +544,6 @@ func getTokenFromGhConfig() string {
 		return ""
 	}
 
-	hubConfig := make(map[string]interface{})
-	err = yaml.Unmarshal(data, &hubConfig)
-	if err != nil {
-		return ""
-	}
-	users := l(m(hubConfig, "github.com"))
-	if len(users) > 0 {
-		return m(users[0], "oauth_token").(string)
-	}
-	return ""
+	return tokenFromHostConfig(data, webHost())
 
 }
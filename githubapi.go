@@ -1,61 +1,179 @@
 package main
 
-func GetWorkflowRunJobs(org string, repo string, runId string) (map[string]interface{}, error) {
-	apiGetter := func() ([]byte, error) {
-		return readGithubApiV3("https://api.github.com/repos/" + org + "/" + repo + "/actions/runs/" + runId + "/jobs")
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+)
+
+//read the (cached) response of an api call and unmarshal it to the target structure
+func cachedJson(getter conditionalGetter, key string, cacheValidator isCacheValid, target interface{}) error {
+	data, err := cachedConditionalGet(getter, key, cacheValidator)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(data, target)
+	if err != nil {
+		return errors.Wrap(err, "Couldn't parse the response of "+key)
 	}
-	return asJson(cachedGet(apiGetter, org+"-"+repo+"-"+"-actions-runs-"+runId+"-jobs", buildResultCache))
+	return nil
 }
 
-func GetArtifacts(org string, repo string, runId string) (map[string]interface{}, error) {
-	apiGetter := func() ([]byte, error) {
-		return readGithubApiV3("https://api.github.com/repos/" + org + "/" + repo + "/actions/runs/" + runId + "/artifacts")
+func GetWorkflowRunJobs(org string, repo string, runId string) (Jobs, error) {
+	apiGetter := restListGetter(apiUrl("/repos/")+org+"/"+repo+"/actions/runs/"+runId+"/jobs?per_page=100", "jobs")
+	result := Jobs{}
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, "actions-runs-"+runId+"-jobs"), buildResultCache, &result)
+	return result, err
+}
+
+func GetArtifacts(org string, repo string, runId string) (Artifacts, error) {
+	apiGetter := restListGetter(apiUrl("/repos/")+org+"/"+repo+"/actions/runs/"+runId+"/artifacts?per_page=100", "artifacts")
+	result := Artifacts{}
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, "actions-runs-"+runId+"-artifacts"), timeCache3min, &result)
+	return result, err
+}
+
+func GetWorkflow(org string, repo string, workflowId string) (Workflow, error) {
+	apiGetter := restGetter(apiUrl("/repos/") + org + "/" + repo + "/actions/workflows/" + workflowId)
+	result := Workflow{}
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, "workflow-"+workflowId), timeCache3min, &result)
+	return result, err
+}
+
+func GetWorkflows(org string, repo string) (Workflows, error) {
+	apiGetter := restListGetter(apiUrl("/repos/")+org+"/"+repo+"/actions/workflows?per_page=100", "workflows")
+	result := Workflows{}
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, "actions-workflows"), timeCache1day, &result)
+	return result, err
+}
+
+//returns with the numeric id of the workflow defined by id, file name (post-commit.yml), path or display name
+func resolveWorkflowId(org string, repo string, workflow string) (string, error) {
+	if _, err := strconv.ParseInt(workflow, 10, 64); err == nil {
+		return workflow, nil
+	}
+	workflows, err := GetWorkflows(org, repo)
+	if err != nil {
+		return "", err
+	}
+	if id, found := findWorkflow(workflows.Workflows, workflow); found {
+		return id, nil
+	}
+	//the cached list can be outdated (eg. the workflow is just added)
+	err = withoutCache(func() error {
+		workflows, err = GetWorkflows(org, repo)
+		return err
+	})
+	if err != nil {
+		return "", err
 	}
-	return asJson(cachedGet3min(apiGetter, org+"-"+repo+"-"+"-actions-runs-"+runId+"-artifacts"))
+	if id, found := findWorkflow(workflows.Workflows, workflow); found {
+		return id, nil
+	}
+	names := make([]string, 0)
+	for _, w := range workflows.Workflows {
+		names = append(names, path.Base(w.Path)+" ("+w.Name+")")
+	}
+	return "", errors.New("Workflow '" + workflow + "' is not found in " + org + "/" + repo + ". Available workflows: " + strings.Join(names, ", "))
 }
 
-func GetWorkflowRunsOfBranch(org string, repo string, workflowId string, branch string) (map[string]interface{}, error) {
-	cacheKey := org + "-" + repo + "-actions-workflows-" + workflowId + "-runs"
-	url := "https://api.github.com/repos/" + org + "/" + repo + "/actions/workflows/" + workflowId + "/runs?per_page=100"
+func findWorkflow(workflows []Workflow, workflow string) (string, bool) {
+	for _, w := range workflows {
+		if w.Path == workflow || path.Base(w.Path) == workflow {
+			return strconv.FormatInt(w.Id, 10), true
+		}
+	}
+	for _, w := range workflows {
+		if strings.EqualFold(w.Name, workflow) {
+			return strconv.FormatInt(w.Id, 10), true
+		}
+	}
+	return "", false
+}
+
+func GetWorkflowRunsOfBranch(org string, repo string, workflow string, branch string) (WorkflowRuns, error) {
+	workflowId, err := resolveWorkflowId(org, repo, workflow)
+	if err != nil {
+		return WorkflowRuns{}, err
+	}
+	cacheKey := "actions-workflows-" + workflowId + "-runs"
+	url := apiUrl("/repos/") + org + "/" + repo + "/actions/workflows/" + workflowId + "/runs?per_page=100"
 	if branch != "" {
 		cacheKey += "-" + branch
 		url += "&branch=" + branch
 	}
-	apiGetter := func() ([]byte, error) {
-		return readGithubApiV3(url)
-	}
-
-	return asJson(cachedGet3min(apiGetter, cacheKey))
+	apiGetter := restListGetter(url, "workflow_runs")
+	result := WorkflowRuns{}
+	err = cachedJson(apiGetter, repoCacheKey(org, repo, cacheKey), timeCache3min, &result)
+	return result, err
 }
 
-func GetWorkflowRuns(org string, repo string, workflowId string) (map[string]interface{}, error) {
+func GetWorkflowRuns(org string, repo string, workflowId string) (WorkflowRuns, error) {
 	return GetWorkflowRunsOfBranch(org, repo, workflowId, "")
 }
 
-func GetPr(org string, repo string, pullId string) (map[string]interface{}, error) {
-	apiGetter := func() ([]byte, error) {
-		return readGithubApiV3("https://api.github.com/repos/" + org + "/" + repo + "/pulls/" + pullId)
-	}
-	return asJson(cachedGet3min(apiGetter, org+"-"+repo+"-pulls-"+pullId))
+func GetPr(org string, repo string, pullId string) (PullRequest, error) {
+	apiGetter := restGetter(apiUrl("/repos/") + org + "/" + repo + "/pulls/" + pullId)
+	result := PullRequest{}
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, "pulls-"+pullId), timeCache3min, &result)
+	return result, err
 }
 
-func GetPrCommits(org string, repo string, pullId string) ([]interface{}, error) {
-	apiGetter := func() ([]byte, error) {
-		return readGithubApiV3("https://api.github.com/repos/" + org + "/" + repo + "/pulls/" + pullId + "/commits")
-	}
-	return asJsonList(cachedGet3min(apiGetter, org+"-"+repo+"-pulls-"+pullId+"-commits"))
+//open pull requests of a branch (head is in the format owner:branch)
+func GetPrsOfBranch(org string, repo string, headOwner string, branch string) ([]PullRequest, error) {
+	apiGetter := restListGetter(apiUrl("/repos/")+org+"/"+repo+"/pulls?state=open&head="+url.QueryEscape(headOwner+":"+branch), "")
+	result := make([]PullRequest, 0)
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, "pulls-head-"+headOwner+"-"+branch), timeCache3min, &result)
+	return result, err
 }
 
-func GetChecksForCommits(org string, repo string, commitId string) (map[string]interface{}, error) {
-	apiGetter := func() ([]byte, error) {
-		return readGithubApiV3("https://api.github.com/repos/" + org + "/" + repo + "/commits/" + commitId + "/check-runs")
-	}
-	return asJson(cachedGet3min(apiGetter, org+"-"+repo+"-commits-"+commitId+"-check-runs"))
+func GetPrCommits(org string, repo string, pullId string) ([]Commit, error) {
+	apiGetter := restListGetter(apiUrl("/repos/")+org+"/"+repo+"/pulls/"+pullId+"/commits?per_page=100", "")
+	result := make([]Commit, 0)
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, "pulls-"+pullId+"-commits"), timeCache3min, &result)
+	return result, err
 }
 
-func GetAllWorkflowRuns(org string, repo string) (map[string]interface{}, error) {
-	apiGetter := func() ([]byte, error) {
-		return readGithubApiV3("https://api.github.com/repos/" + org + "/" + repo + "/actions/runs?per_page=100")
+func GetChecksForCommits(org string, repo string, commitId string) (CheckRuns, error) {
+	apiGetter := restListGetter(apiUrl("/repos/")+org+"/"+repo+"/commits/"+commitId+"/check-runs?per_page=100", "check_runs")
+	result := CheckRuns{}
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, "commits-"+commitId+"-check-runs"), timeCache3min, &result)
+	return result, err
+}
+
+//workflow runs (of any workflow) of a commit
+func GetWorkflowRunsOfSha(org string, repo string, sha string) (WorkflowRuns, error) {
+	apiGetter := restListGetter(apiUrl("/repos/")+org+"/"+repo+"/actions/runs?per_page=100&head_sha="+sha, "workflow_runs")
+	result := WorkflowRuns{}
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, "actions-runs-sha-"+sha), timeCache3min, &result)
+	return result, err
+}
+
+func GetWorkflowRun(org string, repo string, runId string) (WorkflowRun, error) {
+	apiGetter := restGetter(apiUrl("/repos/") + org + "/" + repo + "/actions/runs/" + runId)
+	result := WorkflowRun{}
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, "actions-runs-"+runId), timeCache3min, &result)
+	return result, err
+}
+
+//remove the cached details of a workflow run which is changed (eg. re-triggered with the same run id)
+func invalidateWorkflowRun(org string, repo string, run WorkflowRun) error {
+	runId := run.IdString()
+	for _, key := range []string{"actions-runs-" + runId, "actions-runs-" + runId + "-jobs", "actions-runs-" + runId + "-artifacts", "actions-runs-sha-" + run.HeadSha} {
+		err := invalidateCacheEntry(repoCacheKey(org, repo, key))
+		if err != nil {
+			return err
+		}
 	}
-	return asJson(cachedGet(apiGetter, org+"-"+repo+"-actions-runs", buildResultCache))
+	return nil
+}
+
+func GetAllWorkflowRuns(org string, repo string) (WorkflowRuns, error) {
+	apiGetter := restListGetter(apiUrl("/repos/")+org+"/"+repo+"/actions/runs?per_page=100", "workflow_runs")
+	result := WorkflowRuns{}
+	err := cachedJson(apiGetter, repoCacheKey(org, repo, "actions-runs"), timeCache3min, &result)
+	return result, err
 }
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextPageUrl(t *testing.T) {
+	header := http.Header{}
+	header.Add("Link", `<https://api.github.com/repositories/1/actions/runs?page=2>; rel="next", <https://api.github.com/repositories/1/actions/runs?page=5>; rel="last"`)
+	assert.Equal(t, "https://api.github.com/repositories/1/actions/runs?page=2", nextPageUrl(header))
+
+	header = http.Header{}
+	header.Add("Link", `<https://api.github.com/repositories/1/actions/runs?page=1>; rel="prev"`)
+	assert.Equal(t, "", nextPageUrl(header))
+}
+
+func TestReadAllPagesOfGithubApiV3(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Add("Link", "<"+server.URL+"/jobs?page=2>; rel=\"next\"")
+			_, _ = w.Write([]byte(`{"total_count": 3, "jobs": [{"name": "a"}, {"name": "b"}]}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"total_count": 3, "jobs": [{"name": "c"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	body, err := readAllPagesOfGithubApiV3(server.URL+"/jobs", "jobs")
+	assert.Nil(t, err)
+	jobs := Jobs{}
+	assert.Nil(t, json.Unmarshal(body, &jobs))
+	assert.Equal(t, 3, jobs.TotalCount)
+	assert.Len(t, jobs.Jobs, 3)
+	assert.Equal(t, "c", jobs.Jobs[2].Name)
+
+	maxPages = 1
+	defer func() { maxPages = 10 }()
+	body, err = readAllPagesOfGithubApiV3(server.URL+"/jobs", "jobs")
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(body, &jobs))
+	assert.Len(t, jobs.Jobs, 2)
+}
+
+//start a fake Github API server with an empty, temporary cache. The returned function restores the original settings
+func withFakeGithub(t *testing.T, handler http.HandlerFunc) func() {
+	server := httptest.NewServer(handler)
+	dir, err := ioutil.TempDir("", "ogh-cache")
+	assert.Nil(t, err)
+	originalCache := os.Getenv("OGH_CACHE")
+	originalUrl := githubApiUrl
+	_ = os.Setenv("OGH_CACHE", dir)
+	githubApiUrl = server.URL
+	return func() {
+		githubApiUrl = originalUrl
+		_ = os.Setenv("OGH_CACHE", originalCache)
+		_ = os.RemoveAll(dir)
+		server.Close()
+	}
+}
+
+func TestResolveWorkflowId(t *testing.T) {
+	requests := 0
+	cleanup := withFakeGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "/repos/apache/ozone/actions/workflows", r.URL.Path)
+		_, _ = w.Write([]byte(`{"total_count": 2, "workflows": [
+			{"id": 8247, "name": "build-branch", "path": ".github/workflows/post-commit.yml"},
+			{"id": 4453, "name": "pr-check", "path": ".github/workflows/pr.yml"}]}`))
+	})
+	defer cleanup()
+
+	id, err := resolveWorkflowId("apache", "ozone", "8247")
+	assert.Nil(t, err)
+	assert.Equal(t, "8247", id)
+	assert.Equal(t, 0, requests)
+
+	id, err = resolveWorkflowId("apache", "ozone", "post-commit.yml")
+	assert.Nil(t, err)
+	assert.Equal(t, "8247", id)
+
+	id, err = resolveWorkflowId("apache", "ozone", ".github/workflows/pr.yml")
+	assert.Nil(t, err)
+	assert.Equal(t, "4453", id)
+
+	id, err = resolveWorkflowId("apache", "ozone", "PR-check")
+	assert.Nil(t, err)
+	assert.Equal(t, "4453", id)
+
+	_, err = resolveWorkflowId("apache", "ozone", "nightly")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "post-commit.yml (build-branch)")
+
+	//the list of the workflows is cached, it's downloaded again only for the unknown workflow
+	assert.Equal(t, 2, requests)
+}
+
+func TestGetAllWorkflowRunsIsRefreshed(t *testing.T) {
+	requests := 0
+	cleanup := withFakeGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"total_count": 1, "workflow_runs": [{"id": 1, "status": "completed"}]}`))
+	})
+	defer cleanup()
+
+	_, err := GetAllWorkflowRuns("apache", "ozone")
+	assert.Nil(t, err)
+	_, err = GetAllWorkflowRuns("apache", "ozone")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests)
+
+	//new runs are listed after the cache is expired
+	entry := path.Join(os.Getenv("OGH_CACHE"), repoCacheKey("apache", "ozone", "actions-runs"))
+	meta := readCacheMeta(entry)
+	meta.FetchedAt = time.Now().Add(-time.Hour)
+	assert.Nil(t, writeCacheMeta(entry, meta))
+	_, err = GetAllWorkflowRuns("apache", "ozone")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, requests)
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"strconv"
+	"time"
+)
+
+//typed representation of the Github REST (v3) responses
+
+type User struct {
+	Login string `json:"login"`
+}
+
+type Repository struct {
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	Owner    User   `json:"owner"`
+}
+
+type HeadCommit struct {
+	Id      string `json:"id"`
+	Message string `json:"message"`
+}
+
+type WorkflowRun struct {
+	Id           int64      `json:"id"`
+	RunNumber    int        `json:"run_number"`
+	RunAttempt   int        `json:"run_attempt"`
+	Name         string     `json:"name"`
+	Event        string     `json:"event"`
+	Status       string     `json:"status"`
+	Conclusion   string     `json:"conclusion"`
+	WorkflowId   int64      `json:"workflow_id"`
+	HeadBranch   string     `json:"head_branch"`
+	HeadSha      string     `json:"head_sha"`
+	HeadCommit   HeadCommit `json:"head_commit"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	HtmlUrl      string     `json:"html_url"`
+	JobsUrl      string     `json:"jobs_url"`
+	ArtifactsUrl string     `json:"artifacts_url"`
+	WorkflowUrl  string     `json:"workflow_url"`
+	RerunUrl     string     `json:"rerun_url"`
+	CancelUrl    string     `json:"cancel_url"`
+	//repository of the workflow run
+	Repository Repository `json:"repository"`
+	//repository of the pushed commit (the fork in case of pull requests from forks)
+	HeadRepository Repository `json:"head_repository"`
+	//pull requests of the run (empty for runs of pull requests from forks)
+	PullRequests []RunPullRequest `json:"pull_requests"`
+}
+
+type RunPullRequest struct {
+	Number int `json:"number"`
+}
+
+func (run WorkflowRun) IdString() string {
+	return strconv.FormatInt(run.Id, 10)
+}
+
+type WorkflowRuns struct {
+	TotalCount   int           `json:"total_count"`
+	WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+}
+
+type Workflow struct {
+	Id    int64  `json:"id"`
+	Name  string `json:"name"`
+	Path  string `json:"path"`
+	State string `json:"state"`
+}
+
+type Workflows struct {
+	TotalCount int        `json:"total_count"`
+	Workflows  []Workflow `json:"workflows"`
+}
+
+type Job struct {
+	Id          int64     `json:"id"`
+	RunId       int64     `json:"run_id"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	HtmlUrl     string    `json:"html_url"`
+}
+
+type Jobs struct {
+	TotalCount int   `json:"total_count"`
+	Jobs       []Job `json:"jobs"`
+}
+
+//true if all the jobs are finished
+func (jobs Jobs) Completed() bool {
+	for _, job := range jobs.Jobs {
+		if job.Status != "completed" {
+			return false
+		}
+	}
+	return true
+}
+
+type Artifact struct {
+	Id                 int64  `json:"id"`
+	Name               string `json:"name"`
+	SizeInBytes        int64  `json:"size_in_bytes"`
+	ArchiveDownloadUrl string `json:"archive_download_url"`
+	Expired            bool   `json:"expired"`
+}
+
+type Artifacts struct {
+	TotalCount int        `json:"total_count"`
+	Artifacts  []Artifact `json:"artifacts"`
+}
+
+type GitRef struct {
+	Ref  string      `json:"ref"`
+	Sha  string      `json:"sha"`
+	Repo *Repository `json:"repo"`
+}
+
+type PullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	Draft   bool   `json:"draft"`
+	User    User   `json:"user"`
+	Head    GitRef `json:"head"`
+	Base    GitRef `json:"base"`
+	HtmlUrl string `json:"html_url"`
+}
+
+type CommitDetails struct {
+	Message string `json:"message"`
+}
+
+type Commit struct {
+	Sha     string        `json:"sha"`
+	Commit  CommitDetails `json:"commit"`
+	HtmlUrl string        `json:"html_url"`
+}
+
+type CheckRun struct {
+	Id         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	HtmlUrl    string `json:"html_url"`
+}
+
+type CheckRuns struct {
+	TotalCount int        `json:"total_count"`
+	CheckRuns  []CheckRun `json:"check_runs"`
+}
+
+//typed representation of the response of pr.graphql
+
+type PageInfo struct {
+	EndCursor   string `json:"endCursor"`
+	HasNextPage bool   `json:"hasNextPage"`
+}
+
+type Actor struct {
+	Login string `json:"login"`
+}
+
+type GraphqlReview struct {
+	UpdatedAt time.Time `json:"updatedAt"`
+	Author    Actor     `json:"author"`
+	State     string    `json:"state"`
+}
+
+type GraphqlComment struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Author    Actor     `json:"author"`
+}
+
+type GraphqlCheckRun struct {
+	Name       string `json:"name"`
+	Conclusion string `json:"conclusion"`
+	Summary    string `json:"summary"`
+	Status     string `json:"status"`
+	Text       string `json:"text"`
+	Title      string `json:"title"`
+}
+
+type GraphqlCheckSuite struct {
+	//the check suite is created when the commit is pushed
+	CreatedAt time.Time `json:"createdAt"`
+	CheckRuns struct {
+		PageInfo PageInfo `json:"pageInfo"`
+		Edges    []struct {
+			Node GraphqlCheckRun `json:"node"`
+		} `json:"edges"`
+	} `json:"checkRuns"`
+}
+
+type GraphqlCommit struct {
+	Message       string    `json:"message"`
+	CommittedDate time.Time `json:"committedDate"`
+	CheckSuites   struct {
+		Edges []struct {
+			Node GraphqlCheckSuite `json:"node"`
+		} `json:"edges"`
+	} `json:"checkSuites"`
+}
+
+type GraphqlPullRequest struct {
+	Title       string    `json:"title"`
+	Number      int       `json:"number"`
+	Mergeable   string    `json:"mergeable"`
+	BaseRefName string    `json:"baseRefName"`
+	HeadRefName string    `json:"headRefName"`
+	Author      Actor     `json:"author"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	IsDraft     bool      `json:"isDraft"`
+	Labels      struct {
+		PageInfo PageInfo `json:"pageInfo"`
+		Nodes    []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Reviews struct {
+		PageInfo PageInfo        `json:"pageInfo"`
+		Nodes    []GraphqlReview `json:"nodes"`
+	} `json:"reviews"`
+	ReviewRequests struct {
+		PageInfo PageInfo `json:"pageInfo"`
+		Edges    []struct {
+			Node struct {
+				RequestedReviewer Actor `json:"requestedReviewer"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"reviewRequests"`
+	Comments struct {
+		PageInfo PageInfo         `json:"pageInfo"`
+		Nodes    []GraphqlComment `json:"nodes"`
+	} `json:"comments"`
+	Commits struct {
+		Edges []struct {
+			Node struct {
+				Commit GraphqlCommit `json:"commit"`
+			} `json:"node"`
+		} `json:"edges"`
+	} `json:"commits"`
+	Participants struct {
+		PageInfo PageInfo `json:"pageInfo"`
+		Edges    []struct {
+			Node Actor `json:"node"`
+		} `json:"edges"`
+	} `json:"participants"`
+}
+
+//names of the labels of the pull request
+func (pr GraphqlPullRequest) LabelNames() []string {
+	labels := make([]string, 0)
+	for _, label := range pr.Labels.Nodes {
+		labels = append(labels, label.Name)
+	}
+	return labels
+}
+
+//commit date of the last commit (zero if there are no commits)
+func (pr GraphqlPullRequest) lastCommitDate() time.Time {
+	commits := pr.Commits.Edges
+	if len(commits) == 0 {
+		return time.Time{}
+	}
+	return commits[len(commits)-1].Node.Commit.CommittedDate
+}
+
+//time when the last commit is pushed (the commit date is used if there is no check suite)
+func (pr *GraphqlPullRequest) lastPushDate() time.Time {
+	if suite := pr.lastCheckSuite(); suite != nil && !suite.CreatedAt.IsZero() {
+		return suite.CreatedAt
+	}
+	return pr.lastCommitDate()
+}
+
+//check suite of the last commit (or nil if there is no check suite)
+func (pr *GraphqlPullRequest) lastCheckSuite() *GraphqlCheckSuite {
+	commits := pr.Commits.Edges
+	if len(commits) == 0 {
+		return nil
+	}
+	suites := commits[len(commits)-1].Node.Commit.CheckSuites.Edges
+	if len(suites) == 0 {
+		return nil
+	}
+	return &suites[len(suites)-1].Node
+}
+
+//check runs of the last commit
+func (pr GraphqlPullRequest) CheckRuns() []Job {
+	jobs := make([]Job, 0)
+	for _, commitEdge := range pr.Commits.Edges {
+		for _, suite := range commitEdge.Node.Commit.CheckSuites.Edges {
+			for _, run := range suite.Node.CheckRuns.Edges {
+				jobs = append(jobs, Job{
+					Name:       run.Node.Name,
+					Status:     run.Node.Status,
+					Conclusion: run.Node.Conclusion,
+				})
+			}
+		}
+	}
+	return jobs
+}
+
+type GraphqlPullRequests struct {
+	PageInfo PageInfo `json:"pageInfo"`
+	Edges    []struct {
+		Node GraphqlPullRequest `json:"node"`
+	} `json:"edges"`
+}
+
+type PullRequestsResult struct {
+	Data struct {
+		Repository struct {
+			PullRequests GraphqlPullRequests `json:"pullRequests"`
+		} `json:"repository"`
+	} `json:"data"`
+}
+
+type PullRequestSearchResult struct {
+	Data struct {
+		Search GraphqlPullRequests `json:"search"`
+	} `json:"data"`
+}
+
+type PullRequestResult struct {
+	Data struct {
+		Repository struct {
+			PullRequest GraphqlPullRequest `json:"pullRequest"`
+		} `json:"repository"`
+	} `json:"data"`
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadArchivedRun(t *testing.T) {
+	run := WorkflowRun{}
+	err := readJsonFile("testdata/2020/06/11/1020/run.json", &run)
+	assert.Nil(t, err)
+	assert.Equal(t, 1020, run.RunNumber)
+	assert.Equal(t, "failure", run.Conclusion)
+	assert.Equal(t, "132576987", run.IdString())
+
+	jobs := Jobs{}
+	err = readJsonFile("testdata/2020/06/11/1020/job.json", &jobs)
+	assert.Nil(t, err)
+	assert.True(t, jobs.Completed())
+	assert.Equal(t, "author", jobs.Jobs[0].Name)
+	assert.Equal(t, 84.0, jobs.Jobs[0].CompletedAt.Sub(jobs.Jobs[0].StartedAt).Seconds())
+}
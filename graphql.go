@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/markbates/pkger/pkging"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+//one element of the errors array of a graphql response
+type GraphqlError struct {
+	Type    string        `json:"type"`
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path"`
+}
+
+type GraphqlErrors []GraphqlError
+
+func (e GraphqlErrors) Error() string {
+	messages := make([]string, 0)
+	for _, graphqlError := range e {
+		message := graphqlError.Message
+		if graphqlError.Type != "" {
+			message = graphqlError.Type + ": " + message
+		}
+		messages = append(messages, message)
+	}
+	return "Graphql query is failed: " + strings.Join(messages, "; ")
+}
+
+func readGraphqlFile(f pkging.File, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	graphql, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", err
+	}
+	return string(graphql), nil
+}
+
+//post a graphql query with the given variables and returns with the raw response
+func postGraphql(query string, variables map[string]interface{}) ([]byte, error) {
+	client := githubClient()
+
+	queryPayload := make(map[string]interface{})
+	queryPayload["query"] = query
+	queryPayload["variables"] = variables
+	payload, err := json.Marshal(queryPayload)
+	if err != nil {
+		return nil, err
+	}
+
+	url := graphqlUrl()
+	log.Debug().Msgf("Posting graphql query to %s", url)
+	req, err := http.NewRequest("POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Authorization", "token "+GetToken())
+	req.Header.Add("Accept", "application/vnd.github.antiope-preview+json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode > 299 {
+		log.Error().Msgf(string(body))
+		return nil, errors.New("Graphql query is failed (" + resp.Status + "): " + url)
+	}
+	return body, nil
+}
+
+//execute a graphql query and unmarshal the full response (including the data field) to the target
+func queryGraphql(query string, variables map[string]interface{}, target interface{}) error {
+	body, err := postGraphql(query, variables)
+	if err != nil {
+		return err
+	}
+	response := struct {
+		Errors GraphqlErrors `json:"errors"`
+	}{}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return errors.Wrap(err, "Couldn't parse the graphql response")
+	}
+	if len(response.Errors) > 0 {
+		return response.Errors
+	}
+	err = json.Unmarshal(body, target)
+	if err != nil {
+		return errors.Wrap(err, "Couldn't parse the graphql response")
+	}
+	return nil
+}
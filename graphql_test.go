@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphqlUrl(t *testing.T) {
+	defer func(url string) { githubApiUrl = url }(githubApiUrl)
+
+	githubApiUrl = "https://api.github.com"
+	assert.Equal(t, "https://api.github.com/graphql", graphqlUrl())
+
+	githubApiUrl = "https://github.example.com/api/v3/"
+	assert.Equal(t, "https://github.example.com/api/graphql", graphqlUrl())
+}
+
+func TestWebUrl(t *testing.T) {
+	defer func(url string) { githubApiUrl = url }(githubApiUrl)
+	defer func(url string) { githubWebUrl = url }(githubWebUrl)
+
+	githubApiUrl = "https://api.github.com"
+	assert.Equal(t, "https://github.com/apache/ozone/pull/1", webUrl("/apache/ozone/pull/1"))
+	assert.Equal(t, "api.github.com", apiHost())
+	assert.Equal(t, "github.com", webHost())
+
+	githubApiUrl = "https://github.example.com/api/v3/"
+	assert.Equal(t, "https://github.example.com/apache/ozone/pull/1", webUrl("/apache/ozone/pull/1"))
+	assert.Equal(t, "github.example.com", apiHost())
+	assert.Equal(t, "github.example.com/apache/ozone/pulls-1", repoCacheKey("apache", "ozone", "pulls-1"))
+
+	githubWebUrl = "https://git.example.com/"
+	assert.Equal(t, "https://git.example.com/apache/ozone/actions", webUrl("/apache/ozone/actions"))
+	assert.Equal(t, "git.example.com", webHost())
+}
+
+func TestTokenFromHostConfig(t *testing.T) {
+	hosts := []byte(`
+github.com:
+- user: elek
+  oauth_token: public
+github.example.com:
+- user: elek
+  oauth_token: enterprise
+`)
+	assert.Equal(t, "public", tokenFromHostConfig(hosts, "github.com"))
+	assert.Equal(t, "enterprise", tokenFromHostConfig(hosts, "github.example.com"))
+	assert.Equal(t, "", tokenFromHostConfig(hosts, "git.example.com"))
+}
+
+func TestQueryGraphqlErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.URL.Path)
+		payload := make(map[string]interface{})
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+		variables := payload["variables"].(map[string]interface{})
+		if variables["name"] == "missing" {
+			_, _ = w.Write([]byte(`{"data": {"repository": null}, "errors": [{"type": "NOT_FOUND", "message": "Could not resolve to a Repository with the name 'missing'."}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"number": 12, "title": "HDDS-1234. Test"}}}}`))
+	}))
+	defer server.Close()
+	defer func(url string) { githubApiUrl = url }(githubApiUrl)
+	githubApiUrl = server.URL
+
+	result := PullRequestResult{}
+	err := queryGraphql("query", map[string]interface{}{"name": "ozone"}, &result)
+	assert.Nil(t, err)
+	assert.Equal(t, 12, result.Data.Repository.PullRequest.Number)
+
+	err = queryGraphql("query", map[string]interface{}{"name": "missing"}, &result)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "NOT_FOUND: Could not resolve")
+}
+
+func TestReadPullRequestsOfAuthor(t *testing.T) {
+	searches := make([]string, 0)
+	cleanup := withFakeGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		payload := make(map[string]interface{})
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Contains(t, payload["query"], "fragment PullRequestFields on PullRequest")
+		variables := payload["variables"].(map[string]interface{})
+		searches = append(searches, variables["search"].(string))
+		_, _ = w.Write([]byte(`{"data": {"search": {"pageInfo": {"hasNextPage": false}, "edges": [{"node": {"number": 12, "author": {"login": "elek"}}}]}}}`))
+	})
+	defer cleanup()
+
+	prs, err := readPullRequests(Reference{Org: "apache", Repo: "ozone"}, "pr", PrQuery{States: []string{"OPEN"}, Author: "elek"})
+	assert.Nil(t, err)
+	assert.Len(t, prs, 1)
+	assert.Equal(t, 12, prs[0].Number)
+	assert.Equal(t, []string{"repo:apache/ozone is:pr author:elek sort:updated-desc is:open"}, searches)
+
+	_, err = PrQuery{States: []string{"OPEN", "MERGED"}, Author: "elek"}.searchString(Reference{Org: "apache", Repo: "ozone"})
+	assert.NotNil(t, err)
+}
+
+func TestReadPullRequestsLabelPages(t *testing.T) {
+	cleanup := withFakeGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		payload := make(map[string]interface{})
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+		variables := payload["variables"].(map[string]interface{})
+		if _, found := variables["labels"]; found {
+			assert.Equal(t, true, variables["labels"])
+			assert.Equal(t, false, variables["reviews"])
+			assert.Equal(t, "label-cursor", variables["labelsCursor"])
+			_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequest": {"labels": {"pageInfo": {"hasNextPage": false}, "nodes": [{"name": "WIP"}]}}}}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"data": {"repository": {"pullRequests": {"pageInfo": {"hasNextPage": false}, "edges": [{"node": {"number": 12,
+			"labels": {"pageInfo": {"hasNextPage": true, "endCursor": "label-cursor"}, "nodes": [{"name": "documentation"}]}}}]}}}}`))
+	})
+	defer cleanup()
+
+	prs, err := readPullRequests(Reference{Org: "apache", Repo: "ozone"}, "pr", PrQuery{States: []string{"OPEN"}})
+	assert.Nil(t, err)
+	assert.Len(t, prs, 1)
+	assert.Equal(t, []string{"documentation", "WIP"}, prs[0].LabelNames())
+}
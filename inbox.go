@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//reasons why a pull request is waiting for the user
+const (
+	inboxReviewRequested = "review requested"
+	inboxNewCommits      = "new commits since your last review"
+	inboxAuthorReplied   = "author replied since your last review"
+)
+
+//one pull request which is waiting for the action of the user
+type inboxEntry struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Author    string    `json:"author"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Reasons   []string  `json:"reasons"`
+}
+
+//time of the last review or comment of the user (zero if the user didn't participate)
+func lastActivityOf(pr GraphqlPullRequest, user string) time.Time {
+	last := time.Time{}
+	for _, review := range pr.Reviews.Nodes {
+		if sameLogin(review.Author.Login, user) && review.UpdatedAt.After(last) {
+			last = review.UpdatedAt
+		}
+	}
+	for _, comment := range pr.Comments.Nodes {
+		if sameLogin(comment.Author.Login, user) && comment.CreatedAt.After(last) {
+			last = comment.CreatedAt
+		}
+	}
+	return last
+}
+
+//returns with the reasons why the pull request waits for the user (empty if it doesn't)
+func inboxReasons(pr GraphqlPullRequest, user string) []string {
+	reasons := make([]string, 0)
+	author := prAuthor(pr)
+	if sameLogin(author, user) {
+		return reasons
+	}
+	if containsString(reviewRequests(pr), user) {
+		reasons = append(reasons, inboxReviewRequested)
+	}
+
+	//approved pull requests are not waiting for the user any more
+	for reviewer, review := range lastReviewsPerUser(pr) {
+		if sameLogin(reviewer, user) && review.State == "APPROVED" {
+			return reasons
+		}
+	}
+	since := lastActivityOf(pr, user)
+	if since.IsZero() {
+		return reasons
+	}
+	//commit date can be older than the push (eg. rebased or amended commits)
+	if pr.lastPushDate().After(since) {
+		reasons = append(reasons, inboxNewCommits)
+	}
+	if lastActivityOf(pr, author).After(since) {
+		reasons = append(reasons, inboxAuthorReplied)
+	}
+	return reasons
+}
+
+func inboxEntries(prs []GraphqlPullRequest, user string) []inboxEntry {
+	entries := make([]inboxEntry, 0)
+	for _, pr := range prs {
+		reasons := inboxReasons(pr, user)
+		if len(reasons) == 0 {
+			continue
+		}
+		entries = append(entries, inboxEntry{
+			Number:    pr.Number,
+			Title:     pr.Title,
+			Author:    prAuthor(pr),
+			UpdatedAt: pr.UpdatedAt,
+			Reasons:   reasons,
+		})
+	}
+	return entries
+}
+
+//list all the open pull requests which are waiting for the review/answer of the user
+func inbox(user string, reference Reference, format string) error {
+	if user == "" {
+		return errors.New("Github user couldn't be determined. Please set GITHUB_USER or use the user argument")
+	}
+	prs, err := readPullRequests(reference, "pr", PrQuery{States: []string{"OPEN"}})
+	if err != nil {
+		return err
+	}
+	return printInbox(os.Stdout, format, inboxEntries(prs, user))
+}
+
+func printInbox(out io.Writer, format string, entries []inboxEntry) error {
+	switch format {
+	case outputJson:
+		return writeJson(out, entries)
+	case outputCsv, outputTsv:
+		rows := make([][]string, 0)
+		for _, entry := range entries {
+			rows = append(rows, []string{
+				strconv.Itoa(entry.Number),
+				entry.Title,
+				entry.Author,
+				entry.UpdatedAt.Format(time.RFC3339),
+				strings.Join(entry.Reasons, ";"),
+			})
+		}
+		return writeRecords(out, format, []string{"number", "title", "author", "updated", "reasons"}, rows)
+	}
+
+	table := tablewriter.NewWriter(out)
+	table.SetHeader([]string{"ID", "Upd", "Author", "Summary", "Why"})
+	table.SetAutoWrapText(false)
+	for _, entry := range entries {
+		table.Append([]string{
+			fmt.Sprintf("%d", entry.Number),
+			shortDuration(time.Now().Sub(entry.UpdatedAt)),
+			">" + limit(entry.Author, 12),
+			limit(entry.Title, 50),
+			strings.Join(entry.Reasons, ", "),
+		})
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboxReasons(t *testing.T) {
+	pr := readTestPr(t)
+
+	//carol is a requested reviewer
+	assert.Equal(t, []string{inboxReviewRequested}, inboxReasons(pr, "carol"))
+
+	//bob approved it
+	assert.Empty(t, inboxReasons(pr, "bob"))
+
+	//pull requests of the user are not listed
+	assert.Empty(t, inboxReasons(pr, "alice"))
+
+	//changes are requested by dave, alice answered and pushed after that
+	dave := GraphqlReview{Author: Actor{Login: "dave"}, State: "CHANGES_REQUESTED"}
+	dave.UpdatedAt, _ = time.Parse(time.RFC3339, "2020-07-01T11:00:00Z")
+	pr.Reviews.Nodes = append(pr.Reviews.Nodes, dave)
+	//the rebased commit is older than the review, but it's pushed after that
+	pr.Commits.Edges[0].Node.Commit.CommittedDate, _ = time.Parse(time.RFC3339, "2020-07-01T09:00:00Z")
+	pr.lastCheckSuite().CreatedAt, _ = time.Parse(time.RFC3339, "2020-07-01T15:00:00Z")
+	assert.Equal(t, []string{inboxNewCommits, inboxAuthorReplied}, inboxReasons(pr, "dave"))
+
+	//logins are case insensitive
+	assert.Equal(t, []string{inboxNewCommits, inboxAuthorReplied}, inboxReasons(pr, "Dave"))
+	assert.Empty(t, inboxReasons(pr, "Bob"))
+	assert.Empty(t, inboxReasons(pr, "ALICE"))
+
+	//nothing happened since the last comment of dave
+	comment := GraphqlComment{Author: Actor{Login: "dave"}}
+	comment.CreatedAt, _ = time.Parse(time.RFC3339, "2020-07-01T16:00:00Z")
+	pr.Comments.Nodes = append(pr.Comments.Nodes, comment)
+	assert.Empty(t, inboxReasons(pr, "dave"))
+
+	entries := inboxEntries([]GraphqlPullRequest{pr}, "carol")
+	assert.Len(t, entries, 1)
+	assert.Equal(t, 42, entries[0].Number)
+}
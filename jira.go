@@ -2,7 +2,6 @@ package main
 
 import (
 	"encoding/json"
-	"github.com/elek/go-utils/github"
 	"github.com/elek/go-utils/jira"
 	jsonhelper "github.com/elek/go-utils/json"
 	"github.com/pkg/errors"
@@ -14,7 +13,7 @@ import (
 
 func CloseJira(jiraId string) error {
 	jiraApi := jira.Jira{
-		Url: "https://issues.apache.org/jira",
+		Url: currentConfig.Jira.Url,
 	}
 
 	updated := map[string]interface{}{
@@ -22,7 +21,7 @@ func CloseJira(jiraId string) error {
 			map[string]interface{}{
 				"add":
 				map[string]interface{}{
-					"name": "1.1.0",
+					"name": currentConfig.Jira.FixVersion,
 				},
 			},
 		},
@@ -42,21 +41,30 @@ func JiraUser() (string, error) {
 	}
 	return user.Username, nil
 }
-func OpenJira(pullRequestId string, githubProject string) error {
-	jiraProject := JiraNameFromGithubProject(githubProject)
+func OpenJira(ref Reference) error {
+	githubProject := ref.Repo
+	pullRequestId := ref.Id
+	jiraProject := currentConfig.Jira.Project
+	if jiraProject == "" {
+		jiraProject = JiraNameFromGithubProject(githubProject)
+	}
 
 	jiraApi := jira.Jira{
-		Url: "https://issues.apache.org/jira",
+		Url: currentConfig.Jira.Url,
 	}
 
-	pr, err := jsonhelper.AsJson(github.ReadGithubApiV3("https://api.github.com/repos/apache/" + githubProject + "/pulls/" + pullRequestId))
+	org := ref.Org
+	pr, err := GetPr(org, githubProject, pullRequestId)
 	if err != nil {
 		return err
 	}
 
-	title := jsonhelper.MS(pr, "title")
-	body := jsonhelper.MS(pr, "body")
-	pullUrl := "https://github.com/apache/" + githubProject + "/pull/" + pullRequestId
+	title := pr.Title
+	body := pr.Body
+	pullUrl := pr.HtmlUrl
+	if pullUrl == "" {
+		pullUrl = webUrl("/" + org + "/" + githubProject + "/pull/" + pullRequestId)
+	}
 	issuePattern, err := regexp.Compile(jiraProject + "-[0-9]+")
 	if err != nil {
 		return err
@@ -98,14 +106,14 @@ func OpenJira(pullRequestId string, githubProject string) error {
 		patch["title"] = jiraId + ". " + title
 	}
 	if !strings.Contains(body, jiraId) {
-		patch["body"] = "JIRA: https://issues.apache.org/jira/browse/" + jiraId + "\n\n" + body
+		patch["body"] = "JIRA: " + currentConfig.Jira.Url + "/browse/" + jiraId + "\n\n" + body
 	}
 	if len(patch)>0 {
 		patchJson, err := json.Marshal(patch)
 		if err != nil {
 			return err
 		}
-		resp, err := github.CallGithubApiV3WithBody("PATCH", "https://api.github.com/repos/apache/"+githubProject+"/pulls/"+pullRequestId, patchJson)
+		resp, err := callGithubApiV3WithBody("PATCH", apiUrl("/repos/"+org+"/"+githubProject+"/pulls/"+pullRequestId), patchJson)
 		if err != nil {
 			return err
 		}
@@ -2,11 +2,6 @@ package main
 
 import (
 	"fmt"
-	"github.com/go-git/go-billy/v5/memfs"
-	"github.com/go-git/go-billy/v5/osfs"
-	git "github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/plumbing/cache"
-	"github.com/go-git/go-git/v5/storage/filesystem"
 	"github.com/pkg/errors"
 	"os"
 	"os/user"
@@ -14,6 +9,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -25,40 +21,6 @@ var version string
 var commit string
 var date string
 
-//repository object reference in the format org/repo@branch#id
-type Reference struct {
-	Org    string
-	Repo   string
-	Branch string
-	Id     string
-}
-
-func ParseReference(str string) Reference {
-	ref := Reference{
-		Org:    "apache",
-		Repo:   "hadoop-ozone",
-		Branch: "master",
-		Id:     "",
-	}
-	refStr := str
-	if strings.Contains(refStr, "/") {
-		ref.Org = strings.Split(refStr, "/")[0]
-		refStr = refStr[len(ref.Org)+1:]
-	}
-	if strings.Contains(refStr, "#") {
-		ref.Id = strings.Split(refStr, "#")[1]
-		refStr = refStr[0 : len(refStr)-len(ref.Id)-1]
-	}
-	if strings.Contains(refStr, "@") {
-		ref.Branch = strings.Split(refStr, "@")[1]
-		refStr = refStr[0 : len(refStr)-len(ref.Branch)-1]
-	}
-	if len(refStr) > 0 {
-		ref.Repo = refStr
-	}
-	return ref
-}
-
 var app *cli.App = cli.NewApp()
 
 func init() {
@@ -67,69 +29,169 @@ func init() {
 	app.Description = "Various helper scripts to query github API to make the development faster."
 	app.Version = fmt.Sprintf("%s (%s, %s)", version, commit, date)
 
+	app.Flags = []cli.Flag{
+		cli.IntFlag{
+			Name:   "max-pages",
+			Usage:  "Maximum number of pages to read from paginated Github API endpoints",
+			EnvVar: "OGH_MAX_PAGES",
+			Value:  maxPages,
+		},
+		cli.StringFlag{
+			Name:   "api-url",
+			Usage:  "Base url of the Github REST API (use https://HOST/api/v3 for Github Enterprise)",
+			EnvVar: "GITHUB_API_URL",
+			Value:  githubApiUrl,
+		},
+		cli.StringFlag{
+			Name:   "graphql-url",
+			Usage:  "Url of the Github GraphQL API (default: derived from the api-url)",
+			EnvVar: "GITHUB_GRAPHQL_URL",
+		},
+		cli.StringFlag{
+			Name:   "web-url",
+			Usage:  "Base url of the Github web pages (default: derived from the api-url)",
+			EnvVar: "GITHUB_WEB_URL",
+		},
+		cli.BoolFlag{
+			Name:   "refresh, no-cache",
+			Usage:  "Don't use the cached responses (fresh responses are still saved to the cache)",
+			EnvVar: "OGH_REFRESH",
+		},
+		cli.BoolFlag{
+			Name:   "offline",
+			Usage:  "Use only the cached responses (regardless of their age) and never touch the network",
+			EnvVar: "OGH_OFFLINE",
+		},
+		cli.DurationFlag{
+			Name:   "timeout",
+			Usage:  "Timeout of one Github API request",
+			EnvVar: "OGH_TIMEOUT",
+			Value:  requestTimeout,
+		},
+	}
+	app.Before = func(c *cli.Context) error {
+		err := initConfig()
+		if err != nil {
+			return err
+		}
+		maxPages = c.GlobalInt("max-pages")
+		requestTimeout = c.GlobalDuration("timeout")
+		setServerUrls(explicitFlag(c, "api-url"), explicitFlag(c, "graphql-url"), explicitFlag(c, "web-url"))
+		refreshCache = c.GlobalBool("refresh")
+		offline = c.GlobalBool("offline")
+		if refreshCache && offline {
+			return errors.New("--refresh and --offline can't be used together")
+		}
+		return nil
+	}
+
 	app.Commands = append(app.Commands, []cli.Command{
 		{
 			Name:    "review",
 			Aliases: []string{"r"},
 			Usage:   "Show the review queue (all READY pull requests)",
+			Flags:   prListFlags(),
 			Action: func(c *cli.Context) error {
+				options, err := prListOptionsFromContext(c, "")
+				if err != nil {
+					return err
+				}
 				ref := ParseReference(c.Args().Get(0))
-				return run(false, "", ref)
+				return run(false, ref, options)
 			},
 		},
 		{
 			Name:    "pull-requests",
 			Aliases: []string{"pr"},
 			Usage:   "Show all the available pull requests",
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				cli.StringFlag{
 					Name:  "user",
-					Usage: "Github user or organization name",
+					Usage: "Show only the pull requests of this author",
 					Value: "",
 				},
-			},
+			}, prListFlags()...),
 			Action: func(c *cli.Context) error {
+				options, err := prListOptionsFromContext(c, c.String("user"))
+				if err != nil {
+					return err
+				}
 				ref := ParseReference(c.Args().Get(0))
-				return run(true, c.String("username"), ref)
+				return run(true, ref, options)
 			},
 		},
 		{
 			Name:    "mine",
 			Aliases: []string{"m"},
 			Usage:   "Show results of the pr from the current user",
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				cli.StringFlag{
 					Name:  "user",
 					Usage: "Github user name. (Default: current user)",
 					Value: "",
 				},
-			},
+			}, prListFlags()...),
 			Action: func(c *cli.Context) error {
+				options, err := prListOptionsFromContext(c, getUser(c))
+				if err != nil {
+					return err
+				}
 				ref := ParseReference(c.Args().Get(0))
-				return run(true, getUser(c), ref)
+				return run(true, ref, options)
 			},
 		},
 		{
-			Name:  "artifacts",
-			Usage: "Download build artifacts",
+			Name:      "inbox",
+			Aliases:   []string{"i"},
+			Usage:     "Show the pull requests which are waiting for the review/answer of the user",
+			ArgsUsage: "[user] [org/repo]",
 			Flags: []cli.Flag{
+				outputFlag(),
+			},
+			Action: func(c *cli.Context) error {
+				format, err := outputFormat(c)
+				if err != nil {
+					return err
+				}
+				user := c.Args().Get(0)
+				if user == "" {
+					user = currentUser()
+				}
+				return inbox(user, ParseReference(c.Args().Get(1)), format)
+			},
+		},
+		{
+			Name:      "artifacts",
+			Usage:     "Download build artifacts",
+			ArgsUsage: "pr/NUM, run/NUM, NUM (run number), org/repo@branch (last build) or github url",
+			Flags: append([]cli.Flag{
 				cli.StringFlag{
 					Name:  "user",
-					Usage: "Github user or organization name",
-					Value: "apache",
+					Usage: "Github user or organization name (default: org of the configuration)",
 				},
 				cli.StringFlag{
 					Name:  "dir",
 					Usage: "Destination dir to save the downloaded artifacts",
 					Value: "/tmp",
 				},
-				cli.BoolFlag{
-					Name:  "all",
-					Usage: "If not used, only the failed artifacts will be downloaded.",
+				cli.IntFlag{
+					Name:  "parallel",
+					Usage: "Number of the artifacts downloaded at the same time",
+					Value: parallelDownloads,
 				},
-			},
+			}, artifactFilterFlags()...),
 			Action: func(c *cli.Context) error {
-				return downloadArtifacts(c.String("user"), c.Args().Get(0), c.String("dir"), c.Bool("all"))
+				filter, err := artifactFilterFromContext(c)
+				if err != nil {
+					return err
+				}
+				parallelDownloads = c.Int("parallel")
+				arg := c.Args().Get(0)
+				//#NUM is the old syntax of run ids
+				if strings.HasPrefix(arg, "#") {
+					arg = referenceRun + "/" + arg[1:]
+				}
+				return downloadArtifacts(commandReference(c, arg), c.String("dir"), filter)
 			},
 		},
 		{
@@ -140,43 +202,88 @@ func init() {
 			},
 		},
 		{
-			Name:    "builds",
-			Aliases: []string{"b"},
-			Usage:   "Print results of branch builds.",
-			Flags: []cli.Flag{
+			Name:      "builds",
+			Aliases:   []string{"b"},
+			Usage:     "Print results of branch builds.",
+			ArgsUsage: "[org/repo@branch]",
+			Flags: append([]cli.Flag{
 				cli.StringFlag{
 					Name:  "user",
-					Usage: "Github user or organization name",
-					Value: "apache",
+					Usage: "Github user or organization name (default: org of the configuration)",
 				},
 				cli.StringFlag{
 					Name:  "workflow",
-					Usage: "Id of the workflow to list the builds",
-					Value: "8247",
+					Usage: "Id, file name (post-commit.yml) or name of the workflow to list the builds (default: build workflow of the configuration)",
 				},
 				cli.StringFlag{
 					Name:  "branch",
-					Usage: "Check the builds of this specific run",
-					Value: "master",
+					Usage: "Check the builds of this specific run (default: branch of the configuration)",
 				},
-			},
+			}, buildListFlags()...),
 			Action: func(c *cli.Context) error {
-				return listBuilds(c.String("user"), c.String("branch"), c.Int("workflow"))
+				options, err := buildListOptionsFromContext(c)
+				if err != nil {
+					return err
+				}
+				workflow := c.String("workflow")
+				if workflow == "" {
+					workflow = currentConfig.Workflows.Build
+				}
+				return listBuilds(commandReference(c, c.Args().Get(0)), workflow, options)
 			},
 		},
 		{
 			Name:    "upcomming",
 			Aliases: []string{"uc"},
 			Usage:   "Show builds sent to the local fork",
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				cli.StringFlag{
 					Name:  "user",
 					Usage: "Github user or organization name (can be set by GITHUB_USER, default to the local user)",
 					Value: "",
 				},
+			}, buildListFlags()...),
+			Action: func(c *cli.Context) error {
+				options, err := buildListOptionsFromContext(c)
+				if err != nil {
+					return err
+				}
+				return listForkBuilds(getUser(c), options)
+			},
+		},
+		{
+			Name:      "notify",
+			Usage:     "Watch the fork builds and the pull requests of the user and notify when they are finished",
+			ArgsUsage: "[user] [org/repo]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "command",
+					Usage: "Shell command to execute (JSON payload is sent to the standard input)",
+				},
+				cli.BoolFlag{
+					Name:  "desktop",
+					Usage: "Send desktop notification with notify-send",
+				},
+				cli.StringFlag{
+					Name:  "webhook",
+					Usage: "POST the JSON payload to this url",
+				},
+				cli.DurationFlag{
+					Name:  "interval",
+					Usage: "Polling interval",
+					Value: time.Minute,
+				},
 			},
 			Action: func(c *cli.Context) error {
-				return listForkBuilds(getUser(c))
+				hooks := currentConfig.Notify
+				override(&hooks.Command, c.String("command"))
+				override(&hooks.Webhook, c.String("webhook"))
+				hooks.Desktop = hooks.Desktop || c.Bool("desktop")
+				user := c.Args().Get(0)
+				if user == "" {
+					user = currentUser()
+				}
+				return notifyDaemon(user, ParseReference(c.Args().Get(1)), hooks, c.Duration("interval"))
 			},
 		},
 		{
@@ -211,7 +318,12 @@ func init() {
 					Usage: "Open jira for a specific pull request",
 					Action: func(c *cli.Context) error {
 						if c.NArg() > 0 {
-							return OpenJira(c.Args().Get(0), getProject(c))
+							ref := ParseReference(c.Args().Get(0))
+							//only the number of the pull request is defined
+							if numberRE.MatchString(c.Args().Get(0)) {
+								ref.Repo = getProject(c)
+							}
+							return OpenJira(ref)
 						} else {
 							return errors.New("Please specify the pull request ID")
 						}
@@ -236,16 +348,175 @@ func init() {
 			},
 		},
 		{
-			Name:    "rerun",
-			Aliases: []string{"rr"},
-			Usage:   "Rerun a build of the specific PR.",
+			Name:   "cache",
+			Usage:  "Manage the local cache of the Github responses",
 			Action: func(c *cli.Context) error {
-				return rerun("apache", c.Args().Get(0))
+				return printCacheSummary()
+			},
+			Subcommands: []cli.Command{
+				{
+					Name:    "list",
+					Aliases: []string{"ls"},
+					Usage:   "Show the size and the number of the cache entries per org/repo",
+					Action: func(c *cli.Context) error {
+						return printCacheSummary()
+					},
+				},
+				{
+					Name:      "inspect",
+					Usage:     "Show the age and the cache policy of one entry",
+					ArgsUsage: "cache key (eg. apache/ozone/pulls-123)",
+					Action: func(c *cli.Context) error {
+						if c.NArg() > 0 {
+							return inspectCacheEntry(c.Args().Get(0))
+						} else {
+							return errors.New("Please specify the cache key")
+						}
+					},
+				},
+				{
+					Name:  "prune",
+					Usage: "Remove the old cache entries",
+					Flags: []cli.Flag{
+						cli.IntFlag{
+							Name:  "days",
+							Usage: "Remove entries older than this number of days",
+							Value: 7,
+						},
+					},
+					Action: func(c *cli.Context) error {
+						return pruneCache(c.Int("days"))
+					},
+				},
+				{
+					Name:      "clear",
+					Usage:     "Remove all the cache entries of a repository",
+					ArgsUsage: "org/repo",
+					Action: func(c *cli.Context) error {
+						if c.NArg() > 0 {
+							org, repo, err := parseRepoName(c.Args().Get(0))
+							if err != nil {
+								return err
+							}
+							return clearCache(org, repo)
+						} else {
+							return errors.New("Please specify the repository (org/repo)")
+						}
+					},
+				},
+			},
+		},
+		{
+			Name:      "rerun",
+			Aliases:   []string{"rr"},
+			Usage:     "Rerun the builds of a pull request, branch or workflow run.",
+			ArgsUsage: "[NUM (pull request number), org/repo#NUM, run/NUM, org/repo@branch or github url]",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "failed-only",
+					Usage: "Rerun only the failed jobs of the failed runs",
+				},
+				cli.StringFlag{
+					Name:  "job",
+					Usage: "Rerun only the job with this name",
+				},
+				cli.StringFlag{
+					Name:  "workflow",
+					Usage: "Rerun only the runs of this workflow (id, file name or name, default: all workflows)",
+				},
+				cli.StringFlag{
+					Name:  "branch",
+					Usage: "Rerun the builds of the last commit of this branch",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				arg := c.Args().Get(0)
+				ref := commandReference(c, arg)
+				options := rerunOptions{
+					FailedOnly: c.Bool("failed-only"),
+					Job:        c.String("job"),
+					Workflow:   c.String("workflow"),
+					//reference without id (org/repo@branch) means a branch, no argument means the pull request of the checkout
+					Branch: ref.Id == "" && (arg != "" || c.String("branch") != ""),
+				}
+				return rerun(ref, options)
+			},
+		},
+		{
+			Name:      "cancel",
+			Usage:     "Cancel the unfinished builds of a pull request, branch or workflow run.",
+			ArgsUsage: "[NUM (pull request number), org/repo#NUM, run/NUM, org/repo@branch or github url]",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "superseded",
+					Usage: "Cancel only the builds of the older commits of the branch (or pull request)",
+				},
+				cli.StringFlag{
+					Name:  "branch",
+					Usage: "Cancel the builds of this branch",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				arg := c.Args().Get(0)
+				ref := commandReference(c, arg)
+				options := cancelOptions{
+					Superseded: c.Bool("superseded"),
+					//reference without id (org/repo@branch) means a branch, no argument means the pull request of the checkout
+					Branch: ref.Id == "" && (arg != "" || c.String("branch") != ""),
+				}
+				return cancel(ref, options)
+			},
+		},
+		{
+			Name:      "dispatch",
+			Usage:     "Start a workflow_dispatch run of a workflow.",
+			ArgsUsage: "WORKFLOW (id, file name or name) [org/repo@branch]",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "ref",
+					Usage: "Branch or tag to run the workflow on (default: branch of the reference)",
+				},
+				cli.StringSliceFlag{
+					Name:  "field, f",
+					Usage: "Input of the workflow in key=value format (can be repeated)",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				if c.Args().Get(0) == "" {
+					return errors.New("Workflow is not specified")
+				}
+				ref := ParseReference(c.Args().Get(1))
+				if c.String("ref") != "" {
+					ref.Branch = c.String("ref")
+				}
+				inputs, err := parseInputs(c.StringSlice("field"))
+				if err != nil {
+					return err
+				}
+				return dispatch(ref, c.Args().Get(0), inputs)
 			},
 		},
 	}...)
 }
 
+//value of the global flag if it's set (with the flag or the environment variable), otherwise empty
+func explicitFlag(c *cli.Context, name string) string {
+	if c.IsSet(name) {
+		return c.GlobalString(name)
+	}
+	return ""
+}
+
+//set the urls of the Github server: explicit values (flags or environment variables) first, then the configuration, then the defaults
+func setServerUrls(apiUrl string, graphqlUrl string, webUrl string) {
+	override(&githubApiUrl, currentConfig.ApiUrl)
+	override(&githubApiUrl, apiUrl)
+	override(&githubGraphqlUrl, currentConfig.GraphqlUrl)
+	override(&githubGraphqlUrl, graphqlUrl)
+	override(&githubWebUrl, currentConfig.WebUrl)
+	override(&githubWebUrl, webUrl)
+}
+
 func main() {
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
@@ -253,7 +524,12 @@ func main() {
 	if len(os.Args) == 2 {
 		_, err := strconv.Atoi(os.Args[1])
 		if err == nil {
-			err := open.Start("http://github.com/apache/hadoop-ozone/pull/" + os.Args[1])
+			err = initConfig()
+			if err != nil {
+				panic(err)
+			}
+			setServerUrls(os.Getenv("GITHUB_API_URL"), os.Getenv("GITHUB_GRAPHQL_URL"), os.Getenv("GITHUB_WEB_URL"))
+			err = open.Start(webUrl("/" + currentConfig.Org + "/" + currentConfig.Repo + "/pull/" + os.Args[1]))
 			if err != nil {
 				panic(err)
 			}
@@ -269,12 +545,44 @@ func main() {
 
 }
 
+//load the configuration files
+func initConfig() error {
+	conf, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	currentConfig = conf
+	return nil
+}
+
+//parse the reference argument of the command (--user and --branch flags override the parsed values)
+func commandReference(c *cli.Context, arg string) Reference {
+	defaults := ParseReference("")
+	if wd, err := os.Getwd(); err == nil {
+		defaults = checkoutDefaults(wd)
+	}
+	ref := parseReferenceWithDefaults(arg, defaults)
+	if c.String("user") != "" {
+		ref.Org = c.String("user")
+	}
+	if c.String("branch") != "" {
+		ref.Branch = c.String("branch")
+	}
+	return ref
+}
+
 func getUser(c *cli.Context) string {
 	userName := c.String("user")
 
 	if userName == "" {
-		userName = os.Getenv("GITHUB_USER")
+		userName = currentUser()
 	}
+	return userName
+}
+
+//github user name from GITHUB_USER (or the name of the local user)
+func currentUser() string {
+	userName := os.Getenv("GITHUB_USER")
 
 	if userName == "" {
 		user, err := user.Current()
@@ -300,7 +608,8 @@ func findGitDir(dir string) string {
 }
 
 func JiraNameFromGithubProject(githubProject string) string {
-	if strings.HasPrefix(strings.ToLower(githubProject), "ozone-") || strings.ToLower(githubProject) == "ozone" {
+	name := strings.ToLower(githubProject)
+	if strings.HasPrefix(name, "ozone-") || name == "ozone" || name == "hadoop-ozone" {
 		return "HDDS"
 	}
 	project := strings.ReplaceAll(githubProject, "incubator-", "")
@@ -312,36 +621,18 @@ func getProject(c *cli.Context) string {
 	project := c.String("project")
 
 	if project == "" {
-
-		worktree := memfs.New()
-
 		wd, err := os.Getwd()
 		if err == nil {
-
-			st := filesystem.NewStorage(osfs.New(findGitDir(wd)), cache.NewObjectLRUDefault())
-
-			repository, err := git.Open(st, worktree)
+			co, err := detectCheckout(wd)
 			if err != nil {
-				log.Err(err)
-			}
-			remotes, err := repository.Remotes()
-			if err != nil {
-				log.Err(err)
-			}
-			for _, remote := range remotes {
-				if remote.Config().Name == "origin" {
-					remoteUrl := remote.Config().URLs[0]
-					parts := strings.Split(remoteUrl, "/")
-					reponame := parts[len(parts)-1]
-					project = strings.ReplaceAll(reponame, ".git", "")
-				}
+				log.Debug().Msg(err.Error())
 			}
+			project = co.Repo
 		}
-
 	}
 
 	if project == "" {
-		project = "ozone"
+		project = currentConfig.Repo
 	}
 	return project
 }
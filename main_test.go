@@ -41,3 +41,101 @@ func TestParseReference(t *testing.T) {
 	assert.Equal(t, "master", ref.Branch)
 	assert.Equal(t, "", ref.Id)
 }
+
+func TestParseReferenceTypedId(t *testing.T) {
+	ref := ParseReference("pr/123")
+	assert.Equal(t, "apache", ref.Org)
+	assert.Equal(t, "123", ref.Id)
+	assert.Equal(t, referencePr, ref.Kind)
+
+	ref = ParseReference("elek/ozone#run/456")
+	assert.Equal(t, "elek", ref.Org)
+	assert.Equal(t, "ozone", ref.Repo)
+	assert.Equal(t, "456", ref.Id)
+	assert.Equal(t, referenceRun, ref.Kind)
+
+	ref = ParseReference("789")
+	assert.Equal(t, currentConfig.Repo, ref.Repo)
+	assert.Equal(t, "789", ref.Id)
+	assert.Equal(t, "", ref.Kind)
+
+	ref = ParseReference("ozone#run/456")
+	assert.Equal(t, currentConfig.Org, ref.Org)
+	assert.Equal(t, "ozone", ref.Repo)
+	assert.Equal(t, "456", ref.Id)
+	assert.Equal(t, referenceRun, ref.Kind)
+
+	ref = ParseReference("#pr/5")
+	assert.Equal(t, currentConfig.Org, ref.Org)
+	assert.Equal(t, currentConfig.Repo, ref.Repo)
+	assert.Equal(t, "5", ref.Id)
+	assert.Equal(t, referencePr, ref.Kind)
+
+	ref = ParseReference("ozone@feature/x")
+	assert.Equal(t, currentConfig.Org, ref.Org)
+	assert.Equal(t, "ozone", ref.Repo)
+	assert.Equal(t, "feature/x", ref.Branch)
+
+	ref = ParseReference("elek/ozone@feature/x")
+	assert.Equal(t, "elek", ref.Org)
+	assert.Equal(t, "ozone", ref.Repo)
+	assert.Equal(t, "feature/x", ref.Branch)
+}
+
+func TestParseRepoName(t *testing.T) {
+	org, repo, err := parseRepoName("elek/ozone")
+	assert.Nil(t, err)
+	assert.Equal(t, "elek", org)
+	assert.Equal(t, "ozone", repo)
+
+	for _, invalid := range []string{"ozone", "elek/", "elek/ozone@HDDS-1", "elek/ozone#123", "a/b/c"} {
+		_, _, err = parseRepoName(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestParseReferenceUrl(t *testing.T) {
+	ref := ParseReference("https://github.com/apache/ozone/pull/123")
+	assert.Equal(t, "apache", ref.Org)
+	assert.Equal(t, "ozone", ref.Repo)
+	assert.Equal(t, "123", ref.Id)
+	assert.Equal(t, referencePr, ref.Kind)
+
+	ref = ParseReference("https://github.com/elek/ozone/actions/runs/456/jobs/789")
+	assert.Equal(t, "elek", ref.Org)
+	assert.Equal(t, "456", ref.Id)
+	assert.Equal(t, referenceRun, ref.Kind)
+
+	ref = ParseReference("https://github.example.com/apache/ozone/tree/feature/HDDS-1")
+	assert.Equal(t, "ozone", ref.Repo)
+	assert.Equal(t, "feature/HDDS-1", ref.Branch)
+	assert.Equal(t, "", ref.Id)
+
+	ref = ParseReference("https://github.com/apache/ratis.git")
+	assert.Equal(t, "ratis", ref.Repo)
+	assert.Equal(t, currentConfig.Branch, ref.Branch)
+}
+
+func TestSetServerUrls(t *testing.T) {
+	defer func(url string) { githubApiUrl = url }(githubApiUrl)
+	defer func(url string) { githubGraphqlUrl = url }(githubGraphqlUrl)
+	defer func(url string) { githubWebUrl = url }(githubWebUrl)
+	defer func(conf config) { currentConfig = conf }(currentConfig)
+
+	currentConfig = defaultConfig()
+	setServerUrls("", "", "")
+	assert.Equal(t, "https://api.github.com", githubApiUrl)
+	assert.Equal(t, "", githubWebUrl)
+
+	currentConfig.ApiUrl = "https://github.example.com/api/v3"
+	currentConfig.WebUrl = "https://github.example.com"
+	setServerUrls("", "", "")
+	assert.Equal(t, "https://github.example.com/api/v3", githubApiUrl)
+	assert.Equal(t, "https://github.example.com", githubWebUrl)
+
+	//flags and environment variables override the configuration
+	setServerUrls("https://git.example.com/api/v3", "https://git.example.com/api/graphql", "https://git.example.com")
+	assert.Equal(t, "https://git.example.com/api/v3", githubApiUrl)
+	assert.Equal(t, "https://git.example.com/api/graphql", githubGraphqlUrl)
+	assert.Equal(t, "https://git.example.com", githubWebUrl)
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//kinds of the finished builds
+const (
+	notifyRun = "run"
+	notifyPr  = "pr"
+)
+
+//payload of the notification hooks (one finished fork build or pull request check)
+type notification struct {
+	Kind string `json:"kind"`
+	//org/repo of the build
+	Repo string `json:"repo"`
+	//id of the workflow run or number of the pull request
+	Id         string    `json:"id"`
+	Title      string    `json:"title"`
+	Branch     string    `json:"branch"`
+	Url        string    `json:"url"`
+	Conclusion string    `json:"conclusion"`
+	FinishedAt time.Time `json:"finishedAt"`
+	//same status string which is printed in the tables
+	Checks     string   `json:"checks"`
+	FailedJobs []string `json:"failedJobs"`
+}
+
+//remembers which builds are finished to notify only about the transitions
+type completionTracker struct {
+	initialized bool
+	completed   map[string]bool
+}
+
+func newCompletionTracker() *completionTracker {
+	return &completionTracker{
+		completed: make(map[string]bool),
+	}
+}
+
+//forget the builds which are not listed any more (merged pull requests, old runs)
+func (t *completionTracker) retain(keys map[string]bool) {
+	for key := range t.completed {
+		if !keys[key] {
+			delete(t.completed, key)
+		}
+	}
+}
+
+//returns true if the build is finished since the previous poll (builds which are already finished at the first poll are ignored)
+func (t *completionTracker) finished(key string, completed bool) bool {
+	previous, found := t.completed[key]
+	t.completed[key] = completed
+	if !completed {
+		return false
+	}
+	if found {
+		return !previous
+	}
+	return t.initialized
+}
+
+func jobFailed(job Job) bool {
+	if strings.ToLower(job.Status) != "completed" {
+		return false
+	}
+	switch strings.ToLower(job.Conclusion) {
+	case "success", "neutral", "skipped", "cancelled":
+		return false
+	}
+	return true
+}
+
+func failedJobNames(jobs []Job) []string {
+	names := make([]string, 0)
+	for _, job := range jobs {
+		if jobFailed(job) {
+			names = append(names, job.Name)
+		}
+	}
+	return names
+}
+
+func jobsCompleted(jobs []Job) bool {
+	for _, job := range jobs {
+		if strings.ToLower(job.Status) != "completed" {
+			return false
+		}
+	}
+	return len(jobs) > 0
+}
+
+func conclusionOf(jobs []Job) string {
+	if len(failedJobNames(jobs)) > 0 {
+		return "failure"
+	}
+	return "success"
+}
+
+func runNotification(repo string, detail runDetails, now time.Time) notification {
+	run := detail.Run
+	conclusion := strings.ToLower(run.Conclusion)
+	if runFailed(detail) {
+		conclusion = "failure"
+	} else if conclusion == "" {
+		conclusion = "success"
+	}
+	return notification{
+		Kind:       notifyRun,
+		Repo:       repo,
+		Id:         run.IdString(),
+		Title:      strings.Split(run.HeadCommit.Message, "\n")[0],
+		Branch:     run.HeadBranch,
+		Url:        run.HtmlUrl,
+		Conclusion: conclusion,
+		FinishedAt: now,
+		Checks:     stepsAsString(detail.Jobs),
+		FailedJobs: failedJobNames(detail.Jobs),
+	}
+}
+
+func prNotification(reference Reference, pr GraphqlPullRequest, now time.Time) notification {
+	jobs := pr.CheckRuns()
+	return notification{
+		Kind:       notifyPr,
+		Repo:       reference.Org + "/" + reference.Repo,
+		Id:         strconv.Itoa(pr.Number),
+		Title:      pr.Title,
+		Branch:     pr.HeadRefName,
+		Url:        webUrl("/" + reference.Org + "/" + reference.Repo + "/pull/" + strconv.Itoa(pr.Number)),
+		Conclusion: conclusionOf(jobs),
+		FinishedAt: now,
+		Checks:     stepsAsString(jobs),
+		FailedJobs: failedJobNames(jobs),
+	}
+}
+
+//check the fork builds and the pull requests of the user and return with the newly finished ones
+func pollNotifications(user string, reference Reference, tracker *completionTracker) ([]notification, error) {
+	now := time.Now()
+	notifications := make([]notification, 0)
+	//keys of the builds which are still listed
+	keys := make(map[string]bool)
+
+	runs, err := readForkBuilds(user)
+	if err != nil {
+		return nil, err
+	}
+	details, err := readRunDetails(user, currentConfig.ForkRepo, runs)
+	if err != nil {
+		return nil, err
+	}
+	for _, detail := range details {
+		key := notifyRun + "/" + detail.Run.IdString()
+		keys[key] = true
+		if tracker.finished(key, runCompleted(detail)) {
+			notifications = append(notifications, runNotification(user+"/"+currentConfig.ForkRepo, detail, now))
+		}
+	}
+
+	prs, err := readPullRequests(reference, "pr", PrQuery{States: []string{"OPEN"}, Author: user})
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range prs {
+		//new pushes are tracked separately
+		key := notifyPr + "/" + strconv.Itoa(pr.Number) + "@" + pr.lastPushDate().Format(time.RFC3339)
+		keys[key] = true
+		if tracker.finished(key, jobsCompleted(pr.CheckRuns())) {
+			notifications = append(notifications, prNotification(reference, pr, now))
+		}
+	}
+	tracker.retain(keys)
+	return notifications, nil
+}
+
+//poll the builds of the user forever and call the hooks when a build is finished
+func notifyDaemon(user string, reference Reference, hooks notifyConfig, interval time.Duration) error {
+	if user == "" {
+		return errors.New("Github user couldn't be determined. Please set GITHUB_USER or use the user argument")
+	}
+	tracker := newCompletionTracker()
+	fmt.Printf("Watching the builds of %s (%s/%s) and the pull requests of %s/%s\n", user, user, currentConfig.ForkRepo, reference.Org, reference.Repo)
+	for {
+		notifications, err := pollNotifications(user, reference, tracker)
+		if err != nil {
+			log.Error().Err(err).Msg("Couldn't check the status of the builds")
+		} else {
+			tracker.initialized = true
+		}
+		for _, n := range notifications {
+			fmt.Printf("%s %s %s %s %s %s\n", n.FinishedAt.Format("15:04:05"), n.Kind, n.Id, n.Branch, n.Conclusion, n.Checks)
+			for _, err := range fireNotification(hooks, n) {
+				log.Error().Err(err).Msg("Notification hook is failed")
+			}
+		}
+		time.Sleep(interval)
+	}
+}
+
+//call all the configured hooks and return with the errors
+func fireNotification(hooks notifyConfig, n notification) []error {
+	errs := make([]error, 0)
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return append(errs, err)
+	}
+	if hooks.Command != "" {
+		if err := runNotifyCommand(hooks.Command, n, payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if hooks.Desktop {
+		if err := sendDesktopNotification(n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if hooks.Webhook != "" {
+		if err := postWebhook(hooks.Webhook, payload); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+//execute the shell command with the payload on the standard input (and the main fields as OGH_* environment variables)
+func runNotifyCommand(command string, n notification, payload []byte) error {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"OGH_KIND="+n.Kind,
+		"OGH_REPO="+n.Repo,
+		"OGH_ID="+n.Id,
+		"OGH_BRANCH="+n.Branch,
+		"OGH_URL="+n.Url,
+		"OGH_CONCLUSION="+n.Conclusion,
+		"OGH_CHECKS="+n.Checks,
+		"OGH_FAILED_JOBS="+strings.Join(n.FailedJobs, ","),
+	)
+	err := cmd.Run()
+	if err != nil {
+		return errors.Wrap(err, "Notification command is failed: "+command)
+	}
+	return nil
+}
+
+func sendDesktopNotification(n notification) error {
+	urgency := "normal"
+	if n.Conclusion == "failure" {
+		urgency = "critical"
+	}
+	summary := fmt.Sprintf("%s %s: %s", n.Kind, n.Id, n.Conclusion)
+	body := n.Branch + " " + n.Checks
+	if len(n.FailedJobs) > 0 {
+		body += "\nFailed: " + strings.Join(n.FailedJobs, ", ")
+	}
+	err := exec.Command("notify-send", "-u", urgency, "-a", "ogh", summary, body).Run()
+	if err != nil {
+		return errors.Wrap(err, "notify-send is failed")
+	}
+	return nil
+}
+
+func postWebhook(url string, payload []byte) error {
+	client := &http.Client{Timeout: requestTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "Webhook call is failed: "+url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode > 299 {
+		return errors.New("Webhook call is failed (" + resp.Status + "): " + url)
+	}
+	return nil
+}
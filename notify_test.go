@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletionTracker(t *testing.T) {
+	tracker := newCompletionTracker()
+	assert.False(t, tracker.finished("run/1", true))
+	assert.False(t, tracker.finished("run/2", false))
+	tracker.initialized = true
+
+	assert.False(t, tracker.finished("run/1", true))
+	assert.True(t, tracker.finished("run/2", true))
+	assert.False(t, tracker.finished("run/2", true))
+	//new builds which are finished between two polls
+	assert.True(t, tracker.finished("run/3", true))
+
+	//builds which are not listed any more are forgotten
+	tracker.retain(map[string]bool{"run/3": true})
+	assert.Equal(t, map[string]bool{"run/3": true}, tracker.completed)
+}
+
+func TestRunNotification(t *testing.T) {
+	detail := runDetails{
+		Run: WorkflowRun{Id: 42, HeadBranch: "HDDS-1", Status: "completed", Conclusion: "failure", HeadCommit: HeadCommit{Message: "HDDS-1. Fix\n\nDetails"}},
+		Jobs: []Job{
+			{Name: "unit", Status: "completed", Conclusion: "success"},
+			{Name: "acceptance (secure)", Status: "completed", Conclusion: "failure"},
+			{Name: "kubernetes", Status: "completed", Conclusion: "cancelled"},
+		},
+	}
+
+	n := runNotification("elek/ozone", detail, time.Now())
+
+	assert.Equal(t, "42", n.Id)
+	assert.Equal(t, "HDDS-1. Fix", n.Title)
+	assert.Equal(t, "failure", n.Conclusion)
+	assert.Equal(t, "_  s ~", n.Checks)
+	assert.Equal(t, []string{"acceptance (secure)"}, n.FailedJobs)
+}
+
+func TestFireNotification(t *testing.T) {
+	received := notification{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "ogh-notify")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	output := path.Join(dir, "out")
+
+	n := notification{Kind: notifyPr, Id: "123", Branch: "HDDS-1", Conclusion: "success", Checks: "___"}
+	errs := fireNotification(notifyConfig{Webhook: server.URL, Command: "echo $OGH_ID $OGH_CONCLUSION > " + output}, n)
+
+	assert.Empty(t, errs)
+	assert.Equal(t, "123", received.Id)
+	content, err := ioutil.ReadFile(output)
+	assert.Nil(t, err)
+	assert.Equal(t, "123 success\n", string(content))
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"io"
+)
+
+//supported values of the --output flag
+const (
+	outputTable = "table"
+	outputJson  = "json"
+	outputCsv   = "csv"
+	outputTsv   = "tsv"
+)
+
+func outputFlag() cli.Flag {
+	return cli.StringFlag{
+		Name:  "output, o",
+		Usage: "Output format: table, json, csv or tsv",
+		Value: outputTable,
+	}
+}
+
+//returns with the output format of the command or error if it's unknown
+func outputFormat(c *cli.Context) (string, error) {
+	format := c.String("output")
+	switch format {
+	case outputTable, outputJson, outputCsv, outputTsv:
+		return format, nil
+	default:
+		return "", errors.New("Unsupported output format: " + format + " (use table, json, csv or tsv)")
+	}
+}
+
+func writeJson(out io.Writer, value interface{}) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+//write the rows as comma (csv) or tab (tsv) separated records with a header line
+func writeRecords(out io.Writer, format string, header []string, rows [][]string) error {
+	writer := csv.NewWriter(out)
+	if format == outputTsv {
+		writer.Comma = '\t'
+	}
+	err := writer.Write(header)
+	if err != nil {
+		return err
+	}
+	err = writer.WriteAll(rows)
+	if err != nil {
+		return errors.Wrap(err, "Couldn't write the "+format+" output")
+	}
+	return nil
+}
@@ -9,4 +9,4 @@ import (
 	"github.com/markbates/pkger/pkging/mem"
 )
 
-var _ = pkger.Apply(mem.UnmarshalEmbed([]byte(`1f8b08000000000000ffec3b6d73dba8f35fe586b77f357a709cd69eb917495a2bce35eec569fc74d3c920c01231021d20c7f64dbffb7f906c474e1c59697337fdcde80516bb2ccbb22ccbb221ff00caa74281f63f20a43a4a832324629b3032b3451819f4472a411bd89188498e4fa4b82748ab8cc002dd381152ff097504da7b7958a0076302da208694030b7c1408b401b0c0572843a2779987c20e285ff7eb0ba14bc7be821a45a0fd173802df2c70a32123a0ad654ad6409f402538680365a0df304908c784a365fbb7a79286e25daa295376de002ce08b0e654419f650a288cec951288005a0d4740a91563918a494e1751d4114ada9d6fc0b7598d01cbca712ae6b4af0bc667493d7c44af0358f641612bcaecafc2b89d1f6a62ed3acd3b7cd2a1485b5573431e22d3551c002842381290f6d3366115ec4cc80520a69e8a6b17e94be443b2f5298d995b6e7e317daa750d3c8468209b9db1043390ba026ca369a286f34bf663a318977e90423b37426f84cd81a068c3c48aa9ff24a66a1bdd540012f95bd22523011be84b79fb5a99934568985b64542f8bb5030c8c3acbe4b98ca299c131b316af02299854794db4b18b3a3b9072c10e998d99ac409839a000b5091fdd8541845020be44373a2ed486bb3d642653f76aab20926664fe61f7b4a1959c392846461c8959066ad959648f0795ea33c344c348d8931ab8f24d96753e9341366635b48c489244ad9d3b5a85b44987740826b48399136a34aaf116491d5e432d1625bb161ce3107104da26c2a6b18171bb1828f0041bb20f69a4db7f50c6153ae89e490d9043f4089d55332c668a2297ac444312c40dbee1272bc5e86a74d2a0d34238f0d316e3e02a65f0142c705a0380115417707f29a273b70d3f50af09321352be869d1745abb909dcce8a2e0038aee002aee16e1002a7272bc83a11cca651183d4bc08466451e670926c87bee87f180cf7b92194a461bafcd0308e24c65e0cb93df7ecbcf6cb38ac5004e9740a99b02322c94f38b383dd1fcd2d8689aae2192b794f120704ff989f7d814e692c9ec9a73537facd34687cf20bed5441ad972f34ca9493078a3397f6dfb9fbadda9f5b45d9a12053a5a450ca0e1844b3a9a4182eedcccb174f8e28954888cfb6829c6aba22f80e72140979c74d00f526a78ca13a1232b417b65aaac7e9a45cc1298908c4b9b29e12a63cf318fb8e29a8a2f5c74612353647d7be136c339a3938200b8b2894a445701aebf5f1b44571a2b58468879150d93a16518960ac083fed22c99411a419dd1549511e323265348c7646554b85206336591044b253f259d35a375bbc264ae716f0927a5f38cae3fc80361f3ba0e16355ab4d7d7d7ec4343308f3b1e394699ac04c5919e2ef5468821349b95e6f2e4ef46ea4b0a9663f1bad6e911bb1d7387352275264a7b48153c90ac186514cb5b023d37b3100c92bb65a720d8d0665ca753eb175cd4679a0bb86b6ea845ac4d921fdac65adc26778b554e5118f5a72b4fe3cb25fafb00988c8421b87b2752739e2d1b677603b8152914d1c658194532470a166a77aea9eecc21f32d0d809b0c09c702ca4bdb30f3741510451043da71a5522d8d26d38cd03d4d9c76ccbaa749b40a18c389573b209b04ae8a2199e96533c8fad4a880fccd85830e6ca94982805c397d8edec9130cdf6e041ba448ac5f200a167470944b3122a8a397ca1d938e3dc57ee6bcdac5011944a6207145399bea8addc6025e46a2a645c46b4b151c3b00a1d37fcbe59e02b51ba70893657dc3be31b8b37d92d624d5fb8c7164f3b2d894691cc5c2b9d2e6da814c9b67286e0a1e97e25b0996dfb1f509a8cb882946f920487721bbeb812f8650a3b1447b1c019e1804845b35c837be47ae0fbf7ef1698e653df9b5a69dbe64a2f6112fdcd0c09e55361be98684859d68be76993029d05145d11d03e769aef2d101b97d23ef69cac7a97f99a36f01ccf7de778ef1cf7abdb681f3b6da771e41d3b272dafd5f4fecf71db8ef11c54dd6133f929648a648e2ecbf59039689f341defd8025d2e40db6d7c701dcf6958a0c7289f81b69be9998076a3e17ef860815b8a41db751cc702fe637574779740ec80b663813e364c1d0bdc14443f63b3cd4c5a27061468a640fb83054e358d8d28370481b67be27aaee33a2dcf023d6530cd96fbfebddb38f6be5be0ea19e987462e6b46ba9df4770b9c57271ddddda53c550483f65f8ee558ceb76c2dcd3db4ce86d5d9b03a1b5667c3ea6c589d0dabb3617536acce86d5d9b03a1b5667c3ea6c589d0dabb3617536acce86bd980d5b3b4223ce2c3c941a29a6c6be5b00430d411b90071176cf4f43140f1e82462fc17e6b496ea239f69a0cdd8bb01b771ec6c39e98dc7455d76f46c1f0f6a47b7e29c6c3fe3c689ceaa091cc83f896fe7173f6fe9c9e66bc327e170315742e191a0d186af4577ff88b0835ae4fba1f3f3d5c9d3f84a8d18fb03f587da1677fde7e1a7cf97c7e3647719fa1d965f30b3d7b3f89190bfceb936e6770ddffd4b9eddff6a7d7836bd5f5fb098a07f7d867f3e05e84fd4f83afd70d27e95ea83f36636fca64d8e793d155b899e3d312c4add9e489dc4f0bf69913f8b7cf783f96b3140f5d3a1975cb68f46474c927c30e3dc08b8e473d761b0fe2af71474faef7cb9d15bfe3623f9a235a2e7f4ebb984f3c9696d14d4bc7ea2d27c38e3319f6cff06909ddc5e061e257a0f323361ef66f26c3c997f1d0656572c151ef138a3b71f9b8970cc78ce1c69508fcce0a7f148bab8fa77b6d62af0d94d8c8337b189dcdc6a33e9b7cea389fcb642a94f168e040bfb5ac224fc11654e0e12408ab8d31bd79a8c6fba2e718f90fdacc855366a7adb2fe281e78703868dcc683051e0e56f862bb2ee1d5d7d387a7be62bf8d0c66136fb0aaac33bf399ff8b795d77163376bffc4269db50d798357ae535e3ed3e3b4ebb7d26e67b09a8cbaaf93a3688f7e8bc3e1f1abc72fddbfaf5adbd7d1958e5b6e43f781e7eac9b0e9a065a4c6a39ef3859e2eaad98659ebd7d84645ff5528ffc99e3db866e5fbec80cf9e074337d9d97b37b30a7679c6263e666859611d72fa34f0faec957acad61e8eae7f609f9cdd437f700fdd9e0b477db6b59dfb4f6a123385fdc1f29acf4eba173a4217679793cce7dcae7af751ab926dbd894ef6972af146357bee3ca0f337e0b35e3f7306573dcb0e15e42ddcc9b2e25974709e6cf6567299b8643cbc8c9037b89c9cffbc7c95cfdb9232f62236f6f40d1e3657ddf3ad0f5c5e9d57d9a715e77d2006feb175f981b3b652795b5b2cf0bd0fbce67d703158c1612bfd17f8aff0d0d5e3d165f35fe1ed771c3cba526fb3df0be5a2cfc8c5f5bfc137c1fee2708cf98af2aaf8a69ccfcfeffd9f91a56aecf506fd5e2fa7b9a7f656e3217efddae5f70af7c7cfc86c8f3a9351e4fcec399be716caeed90765994d46bd7b14b3872ccf50319edb5b7c36fb99b98cbd568afdc171d5b875bf0c79fc1b347efc5e52d0cdab62dcfdb6f28bee815fe35ef4301e5d3a70d84b90df497fd9bbb3b95f8ccea280cffee7ee47cff1bb7c36edd3eba4751efefe7bf6972649b87eccb43ea655c1e6211ba95fb2d52fd9ea976cf54bb6fa255bfd92ad7ec956bf64ab5fb2d52fd9ea976cf54bb6fa255bfd92ad7ec956bf64ab5fb295fc5fe7ff030000ffff010000ffff39a60fdb334b0000`)))
+var _ = pkger.Apply(mem.UnmarshalEmbed([]byte(`1f8b08000000000000ffec7d5d73a338f6f757d9e2da13308e93d8557b11a72738e94e66dbe9f86d6baa4b800c8a85c44ac22f999aeffe943060b001e374cfd6cef3d705313ae727a1972324249d5ffed0105950aef5ffd03c24fcc8be7068a0430c973af57c29fe8498d6d77446a9d003ea46186a2ded21082913ff02c2d7faa5115bda3308a0d6d7028088d6d23e5147eb6b5a4bfb0698074596a247751b9124ca885271fcac27201c5febff5bbbd07e6f692f0260a8f5058b60121841c029d1fa9a1d21ecfee3e1d33f02c48338524bb3e83dc290cbe880393e5ac10b8f6a2d0d308116c0110b8405644519df05e3e4927b07387e1233be056e80482e8ca9b3fc1e11b44965c48138b9f7a1b3a4914842942c90b7bb77110f653693105d134c81bb0b25759abb0721caab02eaa64ff01808fdff2401446c9ae4e20d3190dc719ae45636c7ee8e508116dbdd3d8d4498e690be539214355c7a30c94f98d451c8f2351632ba403845331e050160499a0c0237bb5d40068993001994c693deb328c9108b482101c100e17be43aadaadf53f3cbb7aafe8e42ada5d95b01b96c244a04dc08ada541e25017114f77f82a1f7c9336930b6f02acb5b44520b4d691417bf4974820cc7559a3b5fa5da239fd0208e4eb0ec59415151efdc5434246b511c65b7dd5d503182cf84914e5d520895d75ebb57a88a3c046c46b08d363033f01e68232e0415d5a03df720183628400b0a50d04e4bab42956ab947f8fb2578e915556c4510c97d1929225d505b0315c33240e9f172e3d1d3246d9413d32aebf434631f5aae4fa918e2f99b44b970a9d8690fce2510c8817df1781115b8015d41d8ca49c864bef02117d0b027cb132b596e68b00eb0206210642be6191b479447544a569692d6df7680285ee0b1126b71193564b654128d7e1063abbbb88c7850e81f0939fb8699230830b0c1d69e90c7a70231363111128900fe694490d17cca14476192e18229e7c04df12993edf720760f9e038caef2ded130ccb3a63b44034df2903f924870621839cebf63b0acdbc6091143d1378bb4e9d85df31b293ce0d10814cc7888b426f77d8361434bbd1c1eec1b1547750e843b60fbb79a5cbc13e001dd72f840a4ad7ec76dbbd9c0063140ae4ec250b14f2f6a5b117f84b77910b052007f6c325dc871011901180759bca6aaf54e8b68d6ab4bc54e950c205202269ea43352482d170abafda17c6855102382ad7a1a658e1655add73823a0446a02e051b7901756b00f1705ba37799edd5a88b2d5fa6e6a04e7f681b258835602e3f07a62f10c475652e5ad7b1ba606e47ea00d79729c04b58d764047101eb1eb003e80b04440d8ad56682fbc0ec5ed5033af5ea6edbac0344b6c0b0062030af4d40ea6b72908ea2156a17865c97b317ca5cc84ee09c303a81f0a80beda8c6d06354c56b2081f880d774054af0b6448b82109788192065062cc5c9f076a8e25b5e8c14b8dd5ca068b307265a8cc89ccb5c201f8dfba05d08154cac6851870674682f02e75e5b02f3a30a2b00365d23d7fb65480f9768939b90e6e7a68093763e6c030e3be6a1e4eab2204104b06dcd04d8a3763ee8c34dddf4584e97b322552ae2680b0c3c5e0fa1a138815823068f106f3c1be88b8a55a1324218e483bb997d36e193d92b9be9ff8b5141c9134058ce6b9396b1917022b682bc09960144424a71032c049b06a8ac46e48b21e927a7e250c76e820a2109bdb039321eba9770bb66e0ac582ca0ec0c7c32fc9e01e7e01cbc7b261e7b2000f89c1825df15b511b23606d8a30c093ff84864e8381f8a96f49133e286c059427146046e2e8b6807d3c85d60c0a0ee20e660ddf5f54d3a1f3a85bbbcbc3981828ea37b14bb48aec9f013e0ac26926f9d46d86460a8c306bb0fad53107d11362978023d5df61818606e73284e4039f24803486e46db007a9cc330f2a2ed4d471a52e09a0190ef6a7d7757bb9072f6420b0c100b0147b2f15dae67df88bc16263f201b2074c018d8265f9bd558c120e4a711c9d8ec4310d682e3aa387339c95978950a9d3b8010c8aa01822e21a9516f43c8cbd5fb05aa537add8738844c777cb900dc141d52bc5d208c4fe27f7c112d031d8fb867aeb4ed167b4f80b2d70a832bc411254df1bbb59f264b783f73a56f0fae5bd33c04cbc527b962dd184f590044b30a3c8ce4a2c5e2cc281e12c823949d9b3fe46e64c9ce8d455cb839330eb5df3ef02439587f24da5260441ac74a3e141b61a9fdb65b796c840ee5c4c2a1382e48f8b158ba034260238cc4f6830970e4427bf749d9283a83abe3a1a21a2ed7ce216b8ace7645ce8e20d79e21f940bc732c681fcb431f7854b2b47d66acec95e8d020a0e4fc043864ab0f3481ceb9df6c63e407b74f1a44d05d2a4e57791a2f800165a7fa831cffd2c94a23688337ef2ecd6c53e33434806c89a160089e096f5e9947319bbca04b222546b86072e7fbccd884ba270d701729d9842900ed68b10098ea3e3c1cc250e00286a81e4096db3c8ea3bdd9506e21c92fb6ddb28a8e0e104bb842c48ed8124a4bff5e361a1feccbd52a4bb27788c82a310021af879eb14b08031bba8d9047fb8915382e5c7a943f21886cb478762ef71e2bf4880321b6154a1611b846aef03fb0adf9667b0bb9d0dd765cddf168c34d4fb4a27a44108795bb9e55f27d6b1d7f8ed5ed99b2887346e50e2096f313865cb0d5576611c421f390ac4ef952d1e59ff84847989cebc823971006405f12ba263e3dfa9efbe00eed5ebe01e47d2b3bc02fc04bc6cf7893f782324fdfe8c972878de97a81b85fa1760017dd2a9d0f1c1f9846953a622b987d84d702f62d926e19952508ddbad492cda43255967aba4b570b929f6ced8e5155ea7415a54c55598d9cfb7a5d2b487d96013b8ef43db4ab4b24f145c3c983e4eb71bfb77ca4ca1ec4d395a64344c8e8667ba8e0db7407e7482c37f0817d94948c210f17e54f0eac0123725bfe6265541c28d8cdcae5e45c072e86ac934a7587399df4cc41d9d1830078d96ff69db70b65658e83c9281edfeb6f61fc0ac910c046852007241fb611df7d09ec255b01012ea491df11cb84bbfe7293ecf2ecc57405e34911130e5d153461940fa627213012b0200f4472f8211379549e1a2b4ad29db543112fcae026840c053b5bcdc96901171cd40a814230e014f24579fce2ce8b428a7121bc3b88c5a04359a1520ed34a8e7e1c163d39fea1034103e494691c8fd1282cd3c00d123ea5cb329d579a96e7c44b5165aa64dc2f910bbf4c1eca23683a0636c4656abe2d4d2d39c1a26344a24d1ec0c10232440b22443c0c1718797ea125f70764f222d9250f2b3799b115c202f2626a498ee41b009255992a7903647299c46e74de8b6473effeaeccbc2222b2643e0449574a0e172df8c121a36456b14b363fc4c74707e3a6495a42fee8bb2328c9ad48b5e96e6f76bf1bdc83dd6eb3fcd183080b1482b8b3c582ff4454403764888864ea44a0383eef24bf0fe370da4932612ea347321d7007a1528d0c99959aec7bb25ccd17ab4447a040691ee5543a5eb8f8e967b4e21b693e22de3e4cac797f97ccfa38460ee40d8f721167dfe9134b9307bbe046c8996636cf9485ca8f1185b01e02c6617a1eaca5450439d4cddde99158b4af8a61b9531011f49f688793f6a9b5b415242e657ad9589d9b263540e5661f75e8781a204799a6b87433be067c344d6a823d915f69642ee1ba4b780039df0dd055c0ac977891e04d70e93ca50e68eabe5c0eab412197800af57ece53a68d8d8943276250b7918bd8ee907925345e80916b9575a0d4d464824d706497de1a82a5f67b4bfb06b9281c1ecf9f17ff2edfbdf953e23941bc887e188ecf8b1784f280785e909c12cf8be2cfed9c203d2f9e172587c673a2ecb4f8914c2ec0e79f999c1bcf49e2c3e3b9b03c2d9e0bee8e8ce704e969f0822839c49d93c547c10b61b96c5710b028ffa0fd51f09c305b76cbc9d685fa489a2d773abce63cf8f137ebe1824bbdf6bccd89661fe35c30281c9fe9b24468b1d501e7908983413aed91b9dbf84f32a7a07c3faa94bdfa256cb7e79f1edf7ddab975f4ffd06a3d3a9e0022a9e745a95788459fa87b20d63d7ab13bb169d13164f106575f6b5fb44dedcf3fff6c6972c8ab723fe9eb21938b53043a0251c22f128b9570e9b6227f5d2880dc26edffa111b9eed6d72ae2b4348edea1d6ef5c5f765b9aec0b5affd234e2dbef714df435d330af7e691bbfb46fbe19377da3d7378d8bb6d9366eccde756f2e9b817f7765c9170073180f98320f9fe04aeb5f750df3b2a53d10aaf5dbedf665bb6bb6b4678cc852ebb7e33a960fefb46f6e5ada2b72b5bed1d2ace477fafd7b085c23be1fb93235a3a5bde4b23bc0cb5dee2f8dde554b1becce36f46f5adaad4081ccc30b74b47efbba67768cab1bf3aaa53d7329b9bebaeeddf4ae4cf3cf96f674046d1bbd2b23856605fdb3a5dd35874ebf7f8f48c4a1abf5ff6db48c96f17bdcaef2a4b5f21e52de43ca7b48790f29ef21e53da4bc8794f790f21e52de43ca7b48790f29ef21e53da4bc8794f790f21e52de43ca7b48790f29ef21e53da4bc8794f790f21e52de43ca7b48790f29ef21e53da4bc8794f790f21e52de43ca7b48790f29ef21e53da4bc8794f790f21e52de43ca7b48790f29ef21e53da4bc8794f790f21efa3fed3d94e453ba2d2dbdcae726ff87a6ca95e8cf96e60201b4bee64cc7d8214bfa68f53a7630defe8606dfdce16368078effe5ee766907f762fe8d7aaf9dd1164cbae4e165ed3d5addb63d79c4ce1bf55e265de3e165fdf9e1eed693d7e3f011bb01c66ee7e9eae1d7c7956d6ef06c7219a7e50463134cc61dc7786e3be479e5bcedd3fd724bbd248d0cf71a8c37ee64fcee0eabd3da639e3fb9d3c777bbf370f570ff6c38018ee6db5cbeace7953d69639b8cde7f43833bdbecf1f9e43eda9567affbda196f1db397af077e876e93bc8dd6b3e9a30126cfa163dd47a5f9b2eeb7ae85dfc074e03748ef0d58e337d07e6cdbe438ad99e9e399295edc49f7543a7c3679c476497e6ceb1ecd279bb2f8e1c390efea67f8881dabf70ea6a395b405bbe346f3e9c3d5c3dd68e59a5deca0b597dac1a3d5f5edc9eb3e6e7c0dd6ee64c3b3b6b8f32377d246491ae9fd411c19efd64bf3f7d99a870e79367e43b79ba74fb7fcc1ba0f5c4bdae371193ebf0c6ec1a4fb660fc7cbf98b1fcedf64be36fe2c187367bbf4e09a7a87cf919763dd93f92b8ee6c14d2546e21eac7134ff35b151af0ee7fb8ed1c57038fa3a9bb8f80e1de8655a77835e853cb2cd1176b683eb727d523fb2debf96e761f1959e7ed6be3fd27980b74ee7ebd5c3a75fd74f776b6f36991b491b6de7d379389fbaf575bc4fabb42d659e1e86037f6e8e1fed60beaac448dcdd00dbc128edb375383a9b3eff369ffac6ab6cbfc67591d65f6f399f3ed5b7f770bc9e5bf7c67c321ab877ebcf47faec1af8ee7444edce437d7af135e0b6e986769dfd0c8d5c1f3ebe9ccec877add7f2fc0c8dcfa7eb6090b6ebcb7c7adf9e4f9f0d67eb0760faf8ee7ea2ded3b7dbf597bb813f2723ece4dbb7fd88e371a1336a660f39fce7972a7b1eac651f79997403fb84cdcf27dd65fadc3a1cb0eedfbf05e34bf75eda5cf37a4afaf8726e8edf4fd868dc47eb31495ba5ef3e6bbc7c4dc6a6f9b4899decae2fe8327ab07ad1c3fdf8fd9c78f1656d5673134777a801b6f27dd4a0ce6ae397c84bd299993d614fc672ecac7b1fbdd9665bcc275d239b2ba0a5f7d5c2d1ccdcb4e7d62b05935911f7ffd97b7fd679c4b3e908cf7fbd376a71d3b101acdeb6898dda568f80c9650d6ed06b5c96cab6c586b3f5f96cba1bcb4bdf1bd6339d4f9ed92b194775ed369f8cc8c977b7d55dcdadd77a4c7c0d625b01d3af0db06979e2f9d73777828df9f489dad6bd7c6f6ebe58f3d01e8eb0b37cecfe8606d7b3e960fd3289c7d5f6d3fbec72f1525da68fbf8bcaaf787cab7ce79eba06f9f9a75737072b9b8b56bfeb9b5d8dfb65a3abe97ca2d9d5686c697495f49d33ae467da0d1d57c2c6b74edbe036ae72fcd2fd937e377c43b98f4a2dcb7e00f5d4e672cecc9fd16e6be4f7fe81a3e1bb3e9a8ede4be2b7fe81a8e301c7efd59756880e98857cd8fff5bf65a350ffff9f1cecb67d3f44fe3ea9f5b15ff585e92ce70e03b6414ce4cbc9e4dba27e6ea07d8fa79d2e1bac5dfe6fbed2f9803c8baeddea11f9f532f1a97a5d8d6a97ef135ecdd79fffc67bcf6c8e4ae77b6aab75fc28b97fc4a16f9629aa0d8cbb93943d0013c23076a9b9df3c8818ccbeb4eb7dd3e931ce8f2f2faa79003c5d93d871ca86d742f3b298d4faf6b5cdf5c35a2064a8ad9841a680f55d4408a1a485103296a20450da4a881143590a20652d4408a1a485103296a20450da4a881143590a20652d4408a1a485103296a20450da4a881143590a20652d4408a1a485103296a20450da4a881143590a20652d4408a1a485103296a20450da4a881143590a20652d4408a1a485103296a20450da4a881143590a20652d4408a1ae82751031df803ed5981e6e4d19f9b9211e7abf73a1c73fb3e6378b0c064cce7c327cf362f0f747bcf6ad7c2869d3257a46c418947bd3d196fe7e6d89f059bc4737e8066d367fc1a8c836f39b69372cff622ab46ea4975ec2d3f683b9664cf182fbf4ebf26f9f0f16c327a994fe6bfcd26edf4d9a1638cb692092509a74c3a07ec04071edd27bce46a192c6ad803d2f224f5b69a5be55ee147ac30a9377ef65bc5faf2bf550e773a58a6ed76ecf57b9a6da58ad16051e2f17bcca6927ae565bf27d9520e3ddeeb3dd8eb3d24ab3dccd3fc9cf2803ce1d13dccfa2c9edf27f6608e4f3145445fd065dcb7c7d367eca0c1f5ff86a7243dc11ef2bf65d7b5ec1dd67ddbb5fc5575dd56310735a99b23f68deb9f624b3bf6b4d0bdabb787bf96316380e7967b9239a571994aaf81ef0c6f1be4e5f01a44724c399fb960f06e0fc779f6bb86d7209cd73255955f92e56a3679f41d73fc785efc41efac3c5acfdbf9a409a3d6598c24d55eeb7f31cb48edfba2d1d5cc0bbefa2af6fdbf9a55e63caffabf96256466f6623603c7c42b1bad3f7f248da40edfdd495bcca68fddf3fbdbcf66011918d2167e2c1fa3d0b5d239f4ed5f6f4f43e3f33971d271e9c771f579ad8d6fb5b1d3793e985f5531438de4fbea57396ed73d2f9e470e9bf52b69bbae35be748765636ef9753c4fadb9ac1176cce72d980e0cc99c73871ac4b91b84f3867697e5bf297e37efa999dbdc9e358f2fbdd26f9cbb0fda4455fc1279219d549ffdd6b3b5fcadbe15e4fc2e66ac5d7e3e6b8e3f344af232e8dd11e3f3c7594d38949ecf8d594d0ee029ab89d96b37243531fbedde85a4f4e85d19867926a949f7c6fc19a426716ecfe434e9f452fe915ec7bce9b6afae6e2a584df2d0b49c15ac261550c56aa2584d14ab89623551ac268ad544b19a285613c56aa2584d14ab89623551ac268ad544b19a285613c56aa2584d14ab89623551ac268ad544b19a285613c56aa2584d14ab89623551ac268ad544b19a285613c56aa2584d14ab89623551ac268ad544b19a285613c56aa2584d14ab89623551ac268ad544b19a285613c56aa2584d7e12abc9813fd09ed5246630214bfa387cc6b3e9e31bf844bdd7ce680b265df2f0b2f61eade47f68bfede57b2faec1fb7c72bf9d991e4dd291ff573b95f187e1a8eb58af570fbfe26fafedf1fd97bb41e60df6fced963f58f7816b8db7bfa1dbe52cf124fe5b7988dd5d465ff0a06d5b9b8c1d6314606c5ba3f7ff8a57585377b0233fb0ae79a61f98d933aeccebce997e60edab9fe207d6357fc80f2ce7b175d20f2c2967133fb03d54f981293f30e507a6fcc0941f98f203537e60ca0fecefe807f6ffd8bb9bdd5481008ee2afe4bd5e4d58dc85a02086d28876b4b3042c2a4c31b1d660d2776f1c68abf895ee9af42c2699d5e43f0ff0cbc181e1c0706038301c180e0c078603c381e1c0706038301c180e0c078603c381e1c0706038301c180e0c078603c381e1c0706038301c180e0c078603c381e1c0706038301c180e0c078603c381fd4407760d80394633547b8c658ee3fe6015aa68ee599d546795c7351456e6feb2689727933fc700eaa14cb5cda71a8fe9ace6675efae0edb56b05cb0a6eb55ddb6f442adbc8e22b791a29b10d9bfe2a768c62369abfc67f5b59b4cc938f9d5e9517dde331bdb19e4bed1fef3a4c915dc2679e65ee62c76ec8e99d866cd57ded9efca3933e2a7b27c52093699973741da3908e2886cf69dbedbf289d04efe68910666f28023bb00d5358db443a592127be4ed3dd2fcc5e20fceef9d4abb9dda7764793960a2fe65daf03b85be9de5398760bc455693a65a4b206f5eac75bfcdb5cc8a49fdff98dd4dcd330ff7f0bcd95006b86c0426021b01058082c0416020b8185c0426021b01058082c0416020b8185c0426021b01058082c0416020b8185c0426021b01058082c0416020b8185c0426021b01058082c0416020b8185c0426021b01058082c0416020b8185c0fadd02ebed1d0000ffff0300908ea2e5385f0100`)))
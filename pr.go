@@ -13,87 +13,105 @@ import (
 )
 
 //list pull requests (all/ready)
-func run(all bool, authorFilter string, reference Reference) error {
+func run(all bool, reference Reference, options prListOptions) error {
 	var key string
-	key = reference.Org + "-" + reference.Repo + "-"
 	if all {
-		key += "pr"
+		key = "pr"
 	} else {
-		key += "review"
+		key = "review"
 	}
-	apiCall := func() ([]byte, error) {
-		return readPrWithGraphql(reference)
+	policy := readyPolicyOf(currentConfig, reference)
+
+	query := PrQuery{States: []string{"OPEN"}, BaseBranch: options.Base}
+	allPrs, err := readPullRequests(reference, key, query)
+	if err != nil {
+		return err
 	}
-	body, err := cachedGet3min(apiCall, key)
+
+	prs := make([]GraphqlPullRequest, 0)
+	for _, pr := range allPrs {
+		if !all && !ready(pr, policy) {
+			continue
+		}
+		if !options.Filter.matches(pr, time.Now(), policy) {
+			continue
+		}
+		prs = append(prs, pr)
+	}
+	err = sortPrs(prs, options.SortBy, options.Reverse, policy)
 	if err != nil {
 		return err
 	}
 
-	result := make(map[string]interface{})
-	json.Unmarshal(body, &result)
+	if options.Format != outputTable {
+		return printPrSummaries(os.Stdout, options.Format, prs, time.Now(), policy)
+	}
 
 	table := tablewriter.NewWriter(os.Stdout)
 	table.SetHeader([]string{"ID", "Cre", "Upd", "Author", "Branch", "Summary", "Participants", "Check"})
 	table.SetAutoWrapText(false)
-	prs := m(result, "data", "repository", "pullRequests", "edges")
-
-	for _, prNode := range l(prs) {
-
-		pr := m(prNode, "node")
-
-		if !all && !ready(pr) {
-			continue
-		}
 
+	for _, pr := range prs {
 		author := prAuthor(pr)
-		participants := getParticipants(pr, author)
+		participants := getParticipants(pr, author, policy)
 		feedback := feedbackCount(participants)
 		statusMark := ""
 		destMark := ""
-		if ms(pr, "baseRefName") != "master" {
-			destMark = "(->" + ms(pr, "baseRefName") + ")"
+		if pr.BaseRefName != "master" && options.Base == "" {
+			destMark = "(->" + pr.BaseRefName + ")"
 		}
-		if ms(pr, "mergeable") == "CONFLICTING" {
+		if pr.Mergeable == "CONFLICTING" {
 			statusMark = "[C] "
 		}
-		if m(pr, "isDraft") == true {
+		if pr.IsDraft {
 			statusMark += "[D]"
 		}
 
-		updated, err := time.Parse(time.RFC3339, ms(pr, "updatedAt"))
-		if err != nil {
-			return errors.Wrap(err, "Can't parse updateAt field of PR:  "+ms(pr, "number"))
-		}
-
-		created, err := time.Parse(time.RFC3339, ms(pr, "createdAt"))
-		if err != nil {
-			return errors.Wrap(err, "Can't parse createdAt field of PR:  "+ms(pr, "number"))
-		}
-
-		inactiveTime := time.Now().Sub(updated)
+		inactiveTime := time.Now().Sub(pr.UpdatedAt)
 
-		if authorFilter == "" || authorFilter == author {
-			prTitle := limit(statusMark+destMark+ms(pr, "title"), 50)
-			if feedback == 0 {
-				prTitle = color.YellowString(prTitle)
-			}
-			table.Append([]string{
-				fmt.Sprintf("%d", int(m(pr, "number").(float64))),
-				shortDuration(time.Now().Sub(created)),
-				shortDuration(inactiveTime),
-				">" + limit(author, 12),
-				ms(pr, "headRefName"),
-				prTitle,
-				strings.Join(participants, ","),
-				buildStatus(pr),
-			})
+		prTitle := limit(statusMark+destMark+pr.Title, 50)
+		if feedback == 0 {
+			prTitle = color.YellowString(prTitle)
 		}
+		table.Append([]string{
+			fmt.Sprintf("%d", pr.Number),
+			shortDuration(time.Now().Sub(pr.CreatedAt)),
+			shortDuration(inactiveTime),
+			">" + limit(author, 12),
+			pr.HeadRefName,
+			prTitle,
+			strings.Join(participants, ","),
+			buildStatus(pr),
+		})
 	}
 	table.Render() // Send output
 
 	return nil
 }
 
+//read the (cached) pull requests matching the query
+func readPullRequests(reference Reference, keyPrefix string, query PrQuery) ([]GraphqlPullRequest, error) {
+	key := repoCacheKey(reference.Org, reference.Repo, keyPrefix+"-"+query.key())
+	apiCall := func() ([]byte, error) {
+		return readPrWithGraphql(reference, query)
+	}
+	body, err := cachedGet3min(apiCall, key)
+	if err != nil {
+		return nil, err
+	}
+
+	result := PullRequestsResult{}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "Couldn't parse the pull request list")
+	}
+	prs := make([]GraphqlPullRequest, 0)
+	for _, edge := range result.Data.Repository.PullRequests.Edges {
+		prs = append(prs, edge.Node)
+	}
+	return prs, nil
+}
+
 func feedbackCount(participants []string) int {
 	i := 0
 	for _, name := range participants {
@@ -120,81 +138,84 @@ func shortDuration(duration time.Duration) string {
 	return res
 }
 
-func ready(pr interface{}) bool {
-	if mb(pr, "isDraft") {
-		return false
-	}
-	for _, review := range lastReviewsPerUser(pr) {
-		state := ms(review, "state")
-		if state == "CHANGES_REQUESTED" {
-			return false
-		}
-	}
+//states of the participants in the participant map
+const (
+	participantRequested        = "REVIEW_REQUESTED"
+	participantParticipated     = "PARTICIPATED"
+	participantChangesRequested = "CHANGES_REQUESTED"
+	participantApproved         = "APPROVED"
+	participantCommented        = "COMMENTED"
+)
 
-	return true
+//symbols of the participant states in the table output
+var participantSymbols = map[string]string{
+	participantRequested:        "?",
+	participantParticipated:     "",
+	participantChangesRequested: "✕",
+	participantApproved:         "✓",
+	participantCommented:        "R",
 }
 
-func getParticipants(pr interface{}, author string) []string {
+//state of all the participants (login -> state), latest review state wins over requests and simple participation
+func participantStates(pr GraphqlPullRequest, policy readyPolicy) map[string]string {
 	reviews := lastReviewsPerUser(pr)
 
 	participants := make(map[string]string)
 
 	for _, login := range reviewRequests(pr) {
-		participants[limit(login, 5)] = "?"
-	}
-
-	for _, participant := range l(m(pr, "participants", "edges")) {
-		login := ms(participant, "node", "login")
-		participants[limit(login, 5)] = ""
+		participants[login] = participantRequested
 	}
 
-	for _, name := range filterReviews(reviews, "CHANGES_REQUESTED") {
-		participants[name] = "✕"
+	for _, participant := range pr.Participants.Edges {
+		participants[participant.Node.Login] = participantParticipated
 	}
 
-	for _, name := range filterReviews(reviews, "APPROVED") {
-		participants[name] = "✓"
+	for _, state := range []string{participantChangesRequested, participantApproved, participantCommented} {
+		for _, review := range reviews {
+			if review.State == state {
+				participants[review.Author.Login] = state
+			}
+		}
 	}
-
-	for _, name := range filterReviews(reviews, "COMMENTED") {
-		participants[name] = "R"
+	for login := range participants {
+		if policy.ignoredUser(login) {
+			delete(participants, login)
+		}
 	}
+	return participants
+}
 
-	comments := l(m(pr, "comments", "nodes"))
-
-	//find last activity
+//login of the user who reviewed or commented the pull request last time
+func lastParticipant(pr GraphqlPullRequest) string {
 	lastActivity := time.Unix(0, 0)
-	lastParticipant := ""
-	for _, review := range reviews {
-		updatedAt, err := time.Parse(time.RFC3339, ms(review, "updatedAt"))
-		if err != nil {
-			println("Unparsable time: " + ms(review, "updatedAt"))
-		}
-		if updatedAt.After(lastActivity) {
-			lastActivity = updatedAt
-			lastParticipant = ms(review, "author", "login")
+	last := ""
+	for _, review := range lastReviewsPerUser(pr) {
+		if review.UpdatedAt.After(lastActivity) {
+			lastActivity = review.UpdatedAt
+			last = review.Author.Login
 		}
 	}
 
-	for _, comment := range comments {
-		createdAt, err := time.Parse(time.RFC3339, ms(comment, "createdAt"))
-		if err != nil {
-			println("Unparsable time: " + ms(comment, "createdAt"))
-		}
-		if createdAt.After(lastActivity) {
-			lastActivity = createdAt
-			lastParticipant = ms(comment, "author", "login")
+	for _, comment := range pr.Comments.Nodes {
+		if comment.CreatedAt.After(lastActivity) {
+			lastActivity = comment.CreatedAt
+			last = comment.Author.Login
 		}
 	}
+	return last
+}
+
+func getParticipants(pr GraphqlPullRequest, author string, policy readyPolicy) []string {
+	participants := make(map[string]string)
+	for login, state := range participantStates(pr, policy) {
+		participants[limit(login, 5)] = participantSymbols[state]
+	}
+	lastParticipant := lastParticipant(pr)
 
 	result := make([]string, 0)
 	ix := 0
 	for _, name := range sortedParticipants(participants) {
 
-		if name == "codec" {
-			continue
-		}
-
 		if name == limit(author, 5) && name != lastParticipant {
 			continue
 		}
@@ -238,31 +259,20 @@ func sortedParticipants(participants map[string]string) []string {
 	return result
 }
 
-func reviewRequests(pr interface{}) []string {
+func reviewRequests(pr GraphqlPullRequest) []string {
 	requests := make([]string, 0)
-	for _, request := range l(m(pr, "reviewRequests", "edges")) {
-		requests = append(requests, ms(request, "node", "requestedReviewer", "login"))
+	for _, request := range pr.ReviewRequests.Edges {
+		requests = append(requests, request.Node.RequestedReviewer.Login)
 	}
 	return requests
 }
 
-func lastReviewsPerUser(pr interface{}) map[string]interface{} {
-	reviewers := make(map[string]interface{})
-	for _, review := range l(m(pr, "reviews", "nodes")) {
-		author := ms(review, "author", "login")
-		if last_review, found := reviewers[author]; found {
-
-			oldRecord, err := time.Parse(time.RFC3339, ms(last_review, "updatedAt"))
-			if err != nil {
-				panic(err)
-			}
-
-			newRecord, err := time.Parse(time.RFC3339, ms(review, "updatedAt"))
-			if err != nil {
-				panic(err)
-			}
-
-			if oldRecord.Before(newRecord) {
+func lastReviewsPerUser(pr GraphqlPullRequest) map[string]GraphqlReview {
+	reviewers := make(map[string]GraphqlReview)
+	for _, review := range pr.Reviews.Nodes {
+		author := review.Author.Login
+		if lastReview, found := reviewers[author]; found {
+			if lastReview.UpdatedAt.Before(review.UpdatedAt) {
 				reviewers[author] = review
 			}
 
@@ -273,19 +283,13 @@ func lastReviewsPerUser(pr interface{}) map[string]interface{} {
 	return reviewers
 }
 
-func filterReviews(reviews map[string]interface{}, status string) []string {
-	result := make([]string, 0)
-	for _, review := range reviews {
-		state := ms(review, "state")
-		if state == status {
-			result = append(result, limit(ms(review, "author", "login"), 5))
-		}
-	}
-	return result
+func prAuthor(pr GraphqlPullRequest) string {
+	return pr.Author.Login
 }
 
-func prAuthor(pr interface{}) string {
-	return ms(pr, "author", "login")
+//Github logins are case insensitive
+func sameLogin(login string, other string) bool {
+	return strings.EqualFold(login, other)
 }
 
 type statusTransform struct {
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//client side filters of the pull request lists
+type prFilter struct {
+	Author string
+	//all of the labels should be present
+	Labels []string
+	//login of a requested reviewer (with pending review request)
+	Reviewer string
+	//show only draft (true) or non-draft (false) pull requests, nil means both
+	Draft *bool
+	//show only conflicting (true) or mergeable (false) pull requests, nil means both
+	Conflicting *bool
+	//minimum time since the last update
+	InactiveFor time.Duration
+	//show only pull requests without any feedback from the reviewers
+	NoFeedback bool
+}
+
+func (filter prFilter) matches(pr GraphqlPullRequest, now time.Time, policy readyPolicy) bool {
+	if filter.Author != "" && !sameLogin(prAuthor(pr), filter.Author) {
+		return false
+	}
+	for _, label := range filter.Labels {
+		if !containsString(pr.LabelNames(), label) {
+			return false
+		}
+	}
+	if filter.Reviewer != "" && !containsString(reviewRequests(pr), filter.Reviewer) {
+		return false
+	}
+	if filter.Draft != nil && *filter.Draft != pr.IsDraft {
+		return false
+	}
+	if filter.Conflicting != nil && *filter.Conflicting != (pr.Mergeable == "CONFLICTING") {
+		return false
+	}
+	if filter.InactiveFor > 0 && now.Sub(pr.UpdatedAt) < filter.InactiveFor {
+		return false
+	}
+	if filter.NoFeedback && feedbackCount(getParticipants(pr, prAuthor(pr), policy)) > 0 {
+		return false
+	}
+	return true
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+//parse age in the format of 3d, 2w or any go duration (eg. 12h)
+func parseAge(age string) (time.Duration, error) {
+	if age == "" {
+		return 0, nil
+	}
+	units := map[string]time.Duration{
+		"d": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour,
+	}
+	for suffix, unit := range units {
+		if strings.HasSuffix(age, suffix) {
+			value, err := strconv.Atoi(strings.TrimSuffix(age, suffix))
+			if err != nil {
+				return 0, errors.New("Invalid age: " + age)
+			}
+			return time.Duration(value) * unit, nil
+		}
+	}
+	duration, err := time.ParseDuration(age)
+	if err != nil {
+		return 0, errors.New("Invalid age: " + age + " (use eg. 12h, 3d or 2w)")
+	}
+	return duration, nil
+}
+
+//rank of the check results for sorting (passed < pending < failed)
+func checkRank(pr GraphqlPullRequest) int {
+	checks := summarizeChecks(pr.CheckRuns())
+	if checks.Failed > 0 {
+		return 2
+	} else if checks.Pending > 0 || checks.Total == 0 {
+		return 1
+	}
+	return 0
+}
+
+//available sort keys with the "less" function of each
+var prSortKeys = map[string]func(a GraphqlPullRequest, b GraphqlPullRequest, policy readyPolicy) bool{
+	//oldest first
+	"created": func(a GraphqlPullRequest, b GraphqlPullRequest, policy readyPolicy) bool {
+		return a.CreatedAt.Before(b.CreatedAt)
+	},
+	//least recently updated first
+	"updated": func(a GraphqlPullRequest, b GraphqlPullRequest, policy readyPolicy) bool {
+		return a.UpdatedAt.Before(b.UpdatedAt)
+	},
+	//fewest feedback first
+	"feedback": func(a GraphqlPullRequest, b GraphqlPullRequest, policy readyPolicy) bool {
+		return feedbackCount(getParticipants(a, prAuthor(a), policy)) < feedbackCount(getParticipants(b, prAuthor(b), policy))
+	},
+	//passing checks first, failing ones last
+	"checks": func(a GraphqlPullRequest, b GraphqlPullRequest, policy readyPolicy) bool {
+		return checkRank(a) < checkRank(b)
+	},
+}
+
+//sort the pull requests by the key (empty key keeps the original order: last updated first)
+func sortPrs(prs []GraphqlPullRequest, key string, reverse bool, policy readyPolicy) error {
+	if key == "" {
+		return nil
+	}
+	less, found := prSortKeys[key]
+	if !found {
+		return errors.New("Unsupported sort key: " + key + " (use created, updated, feedback or checks)")
+	}
+	sort.SliceStable(prs, func(i, j int) bool {
+		if reverse {
+			return less(prs[j], prs[i], policy)
+		}
+		return less(prs[i], prs[j], policy)
+	})
+	return nil
+}
+
+//options of the pull request listing commands (review/pr/mine)
+type prListOptions struct {
+	Filter prFilter
+	//base branch, filtered by the Github API
+	Base    string
+	SortBy  string
+	Reverse bool
+	Format  string
+}
+
+func prListFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:  "base",
+			Usage: "Show only the pull requests of this base branch",
+		},
+		cli.StringSliceFlag{
+			Name:  "label",
+			Usage: "Show only the pull requests with this label (can be repeated)",
+		},
+		cli.StringFlag{
+			Name:  "reviewer",
+			Usage: "Show only the pull requests where the review of this user is requested (@me: current user)",
+		},
+		cli.BoolFlag{
+			Name:  "draft",
+			Usage: "Show only the draft pull requests",
+		},
+		cli.BoolFlag{
+			Name:  "no-draft",
+			Usage: "Hide the draft pull requests",
+		},
+		cli.BoolFlag{
+			Name:  "conflicting",
+			Usage: "Show only the conflicting pull requests",
+		},
+		cli.BoolFlag{
+			Name:  "no-conflicting",
+			Usage: "Hide the conflicting pull requests",
+		},
+		cli.StringFlag{
+			Name:  "inactive",
+			Usage: "Show only the pull requests without any update since the given time (eg. 12h, 3d, 2w)",
+		},
+		cli.BoolFlag{
+			Name:  "no-feedback",
+			Usage: "Show only the pull requests without any feedback",
+		},
+		cli.StringFlag{
+			Name:  "sort",
+			Usage: "Sort by created (oldest first), updated (least recent first), feedback (fewest first) or checks (passing first)",
+		},
+		cli.BoolFlag{
+			Name:  "reverse",
+			Usage: "Reverse the sort order",
+		},
+		outputFlag(),
+	}
+}
+
+//tri-state filter from a pair of --flag / --no-flag options
+func boolFilter(c *cli.Context, name string) (*bool, error) {
+	if c.Bool(name) && c.Bool("no-"+name) {
+		return nil, errors.New("--" + name + " and --no-" + name + " can't be used together")
+	}
+	if c.Bool(name) {
+		value := true
+		return &value, nil
+	}
+	if c.Bool("no-" + name) {
+		value := false
+		return &value, nil
+	}
+	return nil, nil
+}
+
+func prListOptionsFromContext(c *cli.Context, author string) (prListOptions, error) {
+	options := prListOptions{
+		Base:    c.String("base"),
+		SortBy:  c.String("sort"),
+		Reverse: c.Bool("reverse"),
+		Filter: prFilter{
+			Author:     author,
+			Labels:     c.StringSlice("label"),
+			Reviewer:   c.String("reviewer"),
+			NoFeedback: c.Bool("no-feedback"),
+		},
+	}
+	if options.Filter.Reviewer == "@me" {
+		options.Filter.Reviewer = currentUser()
+	}
+	if _, found := prSortKeys[options.SortBy]; options.SortBy != "" && !found {
+		return options, errors.New("Unsupported sort key: " + options.SortBy + " (use created, updated, feedback or checks)")
+	}
+	var err error
+	options.Filter.Draft, err = boolFilter(c, "draft")
+	if err != nil {
+		return options, err
+	}
+	options.Filter.Conflicting, err = boolFilter(c, "conflicting")
+	if err != nil {
+		return options, err
+	}
+	options.Filter.InactiveFor, err = parseAge(c.String("inactive"))
+	if err != nil {
+		return options, err
+	}
+	options.Format, err = outputFormat(c)
+	return options, err
+}
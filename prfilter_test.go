@@ -0,0 +1,75 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrFilter(t *testing.T) {
+	pr := readTestPr(t)
+	pr.Labels.Nodes = append(pr.Labels.Nodes, struct {
+		Name string `json:"name"`
+	}{Name: "documentation"})
+	now, _ := time.Parse(time.RFC3339, "2020-07-05T10:00:00Z")
+	yes := true
+	no := false
+
+	assert.True(t, prFilter{}.matches(pr, now, defaultReadyPolicy))
+	assert.True(t, prFilter{Author: "alice", Labels: []string{"Documentation"}, Reviewer: "carol"}.matches(pr, now, defaultReadyPolicy))
+	assert.True(t, prFilter{Author: "Alice"}.matches(pr, now, defaultReadyPolicy))
+	assert.False(t, prFilter{Author: "bob"}.matches(pr, now, defaultReadyPolicy))
+	assert.False(t, prFilter{Labels: []string{"documentation", "bug"}}.matches(pr, now, defaultReadyPolicy))
+	assert.False(t, prFilter{Reviewer: "bob"}.matches(pr, now, defaultReadyPolicy))
+	assert.False(t, prFilter{Draft: &yes}.matches(pr, now, defaultReadyPolicy))
+	assert.True(t, prFilter{Draft: &no, Conflicting: &no}.matches(pr, now, defaultReadyPolicy))
+	assert.True(t, prFilter{InactiveFor: 48 * time.Hour}.matches(pr, now, defaultReadyPolicy))
+	assert.False(t, prFilter{InactiveFor: 7 * 24 * time.Hour}.matches(pr, now, defaultReadyPolicy))
+	assert.False(t, prFilter{NoFeedback: true}.matches(pr, now, defaultReadyPolicy))
+}
+
+func TestParseAge(t *testing.T) {
+	age, err := parseAge("3d")
+	assert.Nil(t, err)
+	assert.Equal(t, 72*time.Hour, age)
+
+	age, err = parseAge("2w")
+	assert.Nil(t, err)
+	assert.Equal(t, 14*24*time.Hour, age)
+
+	age, err = parseAge("90m")
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Minute, age)
+
+	_, err = parseAge("xd")
+	assert.NotNil(t, err)
+}
+
+func TestSortPrs(t *testing.T) {
+	day := 24 * time.Hour
+	now := time.Now()
+	prs := []GraphqlPullRequest{
+		{Number: 1, CreatedAt: now.Add(-2 * day), UpdatedAt: now.Add(-1 * day)},
+		{Number: 2, CreatedAt: now.Add(-3 * day), UpdatedAt: now},
+		{Number: 3, CreatedAt: now.Add(-1 * day), UpdatedAt: now.Add(-2 * day)},
+	}
+	numbers := func() []int {
+		result := make([]int, 0)
+		for _, pr := range prs {
+			result = append(result, pr.Number)
+		}
+		return result
+	}
+
+	assert.Nil(t, sortPrs(prs, "created", false, defaultReadyPolicy))
+	assert.Equal(t, []int{2, 1, 3}, numbers())
+
+	assert.Nil(t, sortPrs(prs, "updated", true, defaultReadyPolicy))
+	assert.Equal(t, []int{2, 1, 3}, numbers())
+
+	assert.Nil(t, sortPrs(prs, "updated", false, defaultReadyPolicy))
+	assert.Equal(t, []int{3, 1, 2}, numbers())
+
+	assert.NotNil(t, sortPrs(prs, "size", false, defaultReadyPolicy))
+}
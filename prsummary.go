@@ -0,0 +1,144 @@
+package main
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//machine readable representation of one pull request (used by the json/csv/tsv outputs)
+type prSummary struct {
+	Number        int                  `json:"number"`
+	Title         string               `json:"title"`
+	Author        string               `json:"author"`
+	Branch        string               `json:"branch"`
+	Base          string               `json:"base"`
+	Labels        []string             `json:"labels"`
+	CreatedAt     time.Time            `json:"createdAt"`
+	UpdatedAt     time.Time            `json:"updatedAt"`
+	AgeHours      int                  `json:"ageHours"`
+	InactiveHours int                  `json:"inactiveHours"`
+	Draft         bool                 `json:"draft"`
+	Mergeable     string               `json:"mergeable"`
+	Participants  []participantSummary `json:"participants"`
+	FeedbackCount int                  `json:"feedbackCount"`
+	Checks        checkSummary         `json:"checks"`
+}
+
+type participantSummary struct {
+	Login string `json:"login"`
+	State string `json:"state"`
+	//true if the last review/comment is created by this participant
+	Last bool `json:"last"`
+}
+
+type checkSummary struct {
+	Total     int `json:"total"`
+	Passed    int `json:"passed"`
+	Failed    int `json:"failed"`
+	Cancelled int `json:"cancelled"`
+	Pending   int `json:"pending"`
+	//same status string which is printed in the table output
+	Status string `json:"status"`
+}
+
+func summarizeChecks(jobs []Job) checkSummary {
+	summary := checkSummary{
+		Total:  len(jobs),
+		Status: stepsAsString(jobs),
+	}
+	for _, job := range jobs {
+		if strings.ToLower(job.Status) != "completed" {
+			summary.Pending++
+			continue
+		}
+		switch strings.ToLower(job.Conclusion) {
+		case "success", "neutral", "skipped":
+			summary.Passed++
+		case "cancelled":
+			summary.Cancelled++
+		default:
+			summary.Failed++
+		}
+	}
+	return summary
+}
+
+func summarizePr(pr GraphqlPullRequest, now time.Time, policy readyPolicy) prSummary {
+	author := prAuthor(pr)
+	last := lastParticipant(pr)
+	participants := make([]participantSummary, 0)
+	for login, state := range participantStates(pr, policy) {
+		participants = append(participants, participantSummary{
+			Login: login,
+			State: state,
+			Last:  login == last,
+		})
+	}
+	sort.Slice(participants, func(i, j int) bool {
+		return participants[i].Login < participants[j].Login
+	})
+	return prSummary{
+		Number:        pr.Number,
+		Title:         pr.Title,
+		Author:        author,
+		Branch:        pr.HeadRefName,
+		Base:          pr.BaseRefName,
+		Labels:        pr.LabelNames(),
+		CreatedAt:     pr.CreatedAt,
+		UpdatedAt:     pr.UpdatedAt,
+		AgeHours:      int(now.Sub(pr.CreatedAt).Hours()),
+		InactiveHours: int(now.Sub(pr.UpdatedAt).Hours()),
+		Draft:         pr.IsDraft,
+		Mergeable:     pr.Mergeable,
+		Participants:  participants,
+		FeedbackCount: feedbackCount(getParticipants(pr, author, policy)),
+		Checks:        summarizeChecks(pr.CheckRuns()),
+	}
+}
+
+func printPrSummaries(out io.Writer, format string, prs []GraphqlPullRequest, now time.Time, policy readyPolicy) error {
+	summaries := make([]prSummary, 0)
+	for _, pr := range prs {
+		summaries = append(summaries, summarizePr(pr, now, policy))
+	}
+	if format == outputJson {
+		return writeJson(out, summaries)
+	}
+
+	header := []string{"number", "title", "author", "branch", "base", "labels", "created", "updated", "age_hours",
+		"inactive_hours", "draft", "mergeable", "participants", "feedback", "checks_total", "checks_passed",
+		"checks_failed", "checks_cancelled", "checks_pending", "check_status"}
+	rows := make([][]string, 0)
+	for _, summary := range summaries {
+		participants := make([]string, 0)
+		for _, participant := range summary.Participants {
+			participants = append(participants, participant.Login+":"+participant.State)
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(summary.Number),
+			summary.Title,
+			summary.Author,
+			summary.Branch,
+			summary.Base,
+			strings.Join(summary.Labels, ";"),
+			summary.CreatedAt.Format(time.RFC3339),
+			summary.UpdatedAt.Format(time.RFC3339),
+			strconv.Itoa(summary.AgeHours),
+			strconv.Itoa(summary.InactiveHours),
+			strconv.FormatBool(summary.Draft),
+			summary.Mergeable,
+			strings.Join(participants, ";"),
+			strconv.Itoa(summary.FeedbackCount),
+			strconv.Itoa(summary.Checks.Total),
+			strconv.Itoa(summary.Checks.Passed),
+			strconv.Itoa(summary.Checks.Failed),
+			strconv.Itoa(summary.Checks.Cancelled),
+			strconv.Itoa(summary.Checks.Pending),
+			summary.Checks.Status,
+		})
+	}
+	return writeRecords(out, format, header, rows)
+}
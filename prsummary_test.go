@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPr = `{
+  "title": "HDDS-1234. Fix everything",
+  "number": 42,
+  "mergeable": "MERGEABLE",
+  "baseRefName": "master",
+  "headRefName": "HDDS-1234",
+  "author": {"login": "alice"},
+  "createdAt": "2020-07-01T10:00:00Z",
+  "updatedAt": "2020-07-02T10:00:00Z",
+  "reviews": {"nodes": [
+    {"updatedAt": "2020-07-01T12:00:00Z", "author": {"login": "bob"}, "state": "COMMENTED"},
+    {"updatedAt": "2020-07-01T13:00:00Z", "author": {"login": "bob"}, "state": "APPROVED"}
+  ]},
+  "reviewRequests": {"edges": [{"node": {"requestedReviewer": {"login": "carol"}}}]},
+  "comments": {"nodes": [{"createdAt": "2020-07-01T14:00:00Z", "author": {"login": "alice"}}]},
+  "participants": {"edges": [{"node": {"login": "alice"}}, {"node": {"login": "bob"}}]},
+  "commits": {"edges": [{"node": {"commit": {"checkSuites": {"edges": [{"node": {"checkRuns": {"edges": [
+    {"node": {"name": "build", "status": "COMPLETED", "conclusion": "SUCCESS"}},
+    {"node": {"name": "unit", "status": "COMPLETED", "conclusion": "FAILURE"}},
+    {"node": {"name": "acceptance", "status": "IN_PROGRESS"}}
+  ]}}}]}}}}]}
+}`
+
+func readTestPr(t *testing.T) GraphqlPullRequest {
+	pr := GraphqlPullRequest{}
+	assert.Nil(t, json.Unmarshal([]byte(testPr), &pr))
+	return pr
+}
+
+func TestSummarizePr(t *testing.T) {
+	now, _ := time.Parse(time.RFC3339, "2020-07-03T10:00:00Z")
+	summary := summarizePr(readTestPr(t), now, defaultReadyPolicy)
+
+	assert.Equal(t, 42, summary.Number)
+	assert.Equal(t, "alice", summary.Author)
+	assert.Equal(t, 48, summary.AgeHours)
+	assert.Equal(t, 24, summary.InactiveHours)
+	assert.Equal(t, []participantSummary{
+		{Login: "alice", State: participantParticipated, Last: true},
+		{Login: "bob", State: participantApproved},
+		{Login: "carol", State: participantRequested},
+	}, summary.Participants)
+	assert.Equal(t, 3, summary.Checks.Total)
+	assert.Equal(t, 1, summary.Checks.Passed)
+	assert.Equal(t, 1, summary.Checks.Failed)
+	assert.Equal(t, 1, summary.Checks.Pending)
+}
+
+func TestPrintPrSummariesTsv(t *testing.T) {
+	out := bytes.Buffer{}
+	err := printPrSummaries(&out, outputTsv, []GraphqlPullRequest{readTestPr(t)}, time.Now(), defaultReadyPolicy)
+	assert.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2)
+	fields := strings.Split(lines[1], "\t")
+	assert.Equal(t, "42", fields[0])
+	assert.Equal(t, "HDDS-1234. Fix everything", fields[1])
+	assert.Equal(t, "alice:PARTICIPATED;bob:APPROVED;carol:REVIEW_REQUESTED", fields[12])
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"path"
+	"strings"
+)
+
+//rules to decide if a pull request is ready for review (can be configured per repository).
+//Unset fields (nil) are inherited from the other configuration files and the default policy.
+type readyPolicy struct {
+	//draft pull requests are ready
+	AllowDraft *bool `yaml:"allowDraft"`
+	//conflicting pull requests are not ready
+	BlockConflicting *bool `yaml:"blockConflicting"`
+	//job groups which should be green (basic, integration, acceptance, other or all)
+	RequiredChecks []string `yaml:"requiredChecks"`
+	//minimum number of approvals
+	RequiredApprovals *int `yaml:"requiredApprovals"`
+	//pull requests with any of these labels are not ready
+	IgnoredLabels []string `yaml:"ignoredLabels"`
+	//login patterns of bot accounts, their reviews and comments are ignored
+	IgnoredUsers []string `yaml:"ignoredUsers"`
+}
+
+var defaultReadyPolicy = readyPolicy{
+	IgnoredUsers: []string{"codecov*"},
+}
+
+//ready policy of the repository (configuration file on top of the default policy)
+func readyPolicyOf(conf config, reference Reference) readyPolicy {
+	policy := conf.repo(reference.Org, reference.Repo).Ready
+	if policy == nil {
+		return defaultReadyPolicy
+	}
+	return mergeReadyPolicy(defaultReadyPolicy, *policy)
+}
+
+func (policy readyPolicy) allowDraft() bool {
+	return policy.AllowDraft != nil && *policy.AllowDraft
+}
+
+func (policy readyPolicy) blockConflicting() bool {
+	return policy.BlockConflicting != nil && *policy.BlockConflicting
+}
+
+func (policy readyPolicy) requiredApprovals() int {
+	if policy.RequiredApprovals == nil {
+		return 0
+	}
+	return *policy.RequiredApprovals
+}
+
+func (policy readyPolicy) ignoredUser(login string) bool {
+	for _, pattern := range policy.IgnoredUsers {
+		if matched, _ := path.Match(pattern, login); matched {
+			return true
+		}
+	}
+	return false
+}
+
+//true if all the jobs of the group (or all the jobs for "all") are finished successfully
+func checksPassed(jobs []Job, group string) bool {
+	found := false
+	for _, job := range jobs {
+		if group != "all" && jobGroups[jobGroupIndex(job.Name)] != group {
+			continue
+		}
+		found = true
+		if strings.ToLower(job.Status) != "completed" {
+			return false
+		}
+		switch strings.ToLower(job.Conclusion) {
+		case "success", "neutral", "skipped":
+		default:
+			return false
+		}
+	}
+	return found
+}
+
+func ready(pr GraphqlPullRequest, policy readyPolicy) bool {
+	if pr.IsDraft && !policy.allowDraft() {
+		return false
+	}
+	if policy.blockConflicting() && pr.Mergeable == "CONFLICTING" {
+		return false
+	}
+	for _, label := range policy.IgnoredLabels {
+		if containsString(pr.LabelNames(), label) {
+			return false
+		}
+	}
+	approvals := 0
+	for login, review := range lastReviewsPerUser(pr) {
+		if policy.ignoredUser(login) {
+			continue
+		}
+		if review.State == "CHANGES_REQUESTED" {
+			return false
+		}
+		if review.State == "APPROVED" && login != prAuthor(pr) {
+			approvals++
+		}
+	}
+	if approvals < policy.requiredApprovals() {
+		return false
+	}
+	for _, group := range policy.RequiredChecks {
+		if !checksPassed(pr.CheckRuns(), group) {
+			return false
+		}
+	}
+	return true
+}
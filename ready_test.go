@@ -0,0 +1,95 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadyDefaultPolicy(t *testing.T) {
+	pr := readTestPr(t)
+	assert.True(t, ready(pr, defaultReadyPolicy))
+
+	pr.IsDraft = true
+	assert.False(t, ready(pr, defaultReadyPolicy))
+
+	pr = readTestPr(t)
+	pr.Reviews.Nodes = append(pr.Reviews.Nodes, GraphqlReview{Author: Actor{Login: "codecov-commenter"}, State: "CHANGES_REQUESTED"})
+	assert.True(t, ready(pr, defaultReadyPolicy))
+	pr.Reviews.Nodes = append(pr.Reviews.Nodes, GraphqlReview{Author: Actor{Login: "dave"}, State: "CHANGES_REQUESTED"})
+	assert.False(t, ready(pr, defaultReadyPolicy))
+}
+
+func TestReadyConfiguredPolicy(t *testing.T) {
+	pr := readTestPr(t)
+	one := 1
+	two := 2
+	yes := true
+
+	assert.True(t, ready(pr, readyPolicy{RequiredApprovals: &one}))
+	assert.False(t, ready(pr, readyPolicy{RequiredApprovals: &two}))
+	assert.False(t, ready(pr, readyPolicy{IgnoredUsers: []string{"bob"}, RequiredApprovals: &one}))
+
+	//unit tests (basic) are failing, the acceptance tests are still running
+	assert.False(t, ready(pr, readyPolicy{RequiredChecks: []string{"basic"}}))
+	pr.Commits.Edges[0].Node.Commit.CheckSuites.Edges[0].Node.CheckRuns.Edges[1].Node.Conclusion = "SUCCESS"
+	assert.True(t, ready(pr, readyPolicy{RequiredChecks: []string{"basic"}}))
+	assert.False(t, ready(pr, readyPolicy{RequiredChecks: []string{"acceptance"}}))
+	assert.False(t, ready(pr, readyPolicy{RequiredChecks: []string{"integration"}}))
+	assert.False(t, ready(pr, readyPolicy{RequiredChecks: []string{"all"}}))
+
+	pr.Mergeable = "CONFLICTING"
+	assert.True(t, ready(pr, readyPolicy{}))
+	assert.False(t, ready(pr, readyPolicy{BlockConflicting: &yes}))
+
+	pr.Labels.Nodes = append(pr.Labels.Nodes, struct {
+		Name string `json:"name"`
+	}{Name: "WIP"})
+	assert.False(t, ready(pr, readyPolicy{IgnoredLabels: []string{"wip"}}))
+}
+
+func TestReadyPolicyOfConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ogh-config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	file := path.Join(dir, "config.yaml")
+	content := `
+repos:
+  apache/ozone:
+    ready:
+      requiredApprovals: 1
+      requiredChecks: [basic, acceptance]
+      ignoredUsers: ["dependabot*"]
+  apache/ratis:
+    ready:
+      requiredApprovals: 1
+  apache/hadoop:
+    ready:
+      ignoredUsers: []
+`
+	assert.Nil(t, ioutil.WriteFile(file, []byte(content), 0600))
+	conf, err := readConfig(file)
+	assert.Nil(t, err)
+
+	policy := readyPolicyOf(conf, Reference{Org: "apache", Repo: "ozone"})
+	assert.Equal(t, 1, policy.requiredApprovals())
+	assert.Equal(t, []string{"basic", "acceptance"}, policy.RequiredChecks)
+	assert.True(t, policy.ignoredUser("dependabot[bot]"))
+	assert.False(t, policy.ignoredUser("codecov-io"))
+
+	//unset fields are coming from the default policy
+	policy = readyPolicyOf(conf, Reference{Org: "apache", Repo: "ratis"})
+	assert.Equal(t, 1, policy.requiredApprovals())
+	assert.True(t, policy.ignoredUser("codecov-io"))
+
+	assert.False(t, readyPolicyOf(conf, Reference{Org: "apache", Repo: "hadoop"}).ignoredUser("codecov-io"))
+	assert.Equal(t, defaultReadyPolicy, readyPolicyOf(conf, Reference{Org: "apache", Repo: "hbase"}))
+
+	conf, err = readConfig(path.Join(dir, "missing.yaml"))
+	assert.Nil(t, err)
+	assert.Empty(t, conf.Repos)
+}
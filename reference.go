@@ -0,0 +1,121 @@
+package main
+
+import (
+	"github.com/pkg/errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+//type of the object identified by the id of the reference
+const (
+	referencePr  = "pr"
+	referenceRun = "run"
+)
+
+//repository object reference in the format org/repo@branch#id (or a github url)
+type Reference struct {
+	Org    string
+	Repo   string
+	Branch string
+	Id     string
+	//referencePr, referenceRun or empty if the meaning of the id depends on the command
+	Kind string
+}
+
+//typed id, like pr/123 or run/456
+var typedIdRE = regexp.MustCompile(`^(pr|run)/(\d+)$`)
+
+var numberRE = regexp.MustCompile(`^\d+$`)
+
+//Parse reference from the following formats (all the parts are optional, defaults are coming from the configuration):
+//
+//  org/repo@branch#id
+//  123 (id only)
+//  org/repo#pr/123, org/repo#run/456, pr/123, run/456
+//  https://github.com/org/repo/pull/123
+//  https://github.com/org/repo/actions/runs/456
+//  https://github.com/org/repo/tree/branch
+func ParseReference(str string) Reference {
+	return parseReferenceWithDefaults(str, Reference{
+		Org:    currentConfig.Org,
+		Repo:   currentConfig.Repo,
+		Branch: currentConfig.Branch,
+	})
+}
+
+//parse the reference, the undefined parts are coming from the defaults
+func parseReferenceWithDefaults(str string, defaults Reference) Reference {
+	ref := defaults
+	if strings.HasPrefix(str, "https://") || strings.HasPrefix(str, "http://") {
+		return parseGithubUrl(ref, str)
+	}
+	if typedIdRE.MatchString(str) || numberRE.MatchString(str) {
+		ref.setId(str)
+		return ref
+	}
+	//id and branch can contain '/', they are split off before the org
+	refStr := str
+	if strings.Contains(refStr, "#") {
+		parts := strings.SplitN(refStr, "#", 2)
+		refStr = parts[0]
+		ref.setId(parts[1])
+	}
+	if strings.Contains(refStr, "@") {
+		parts := strings.SplitN(refStr, "@", 2)
+		refStr = parts[0]
+		ref.Branch = parts[1]
+	}
+	if strings.Contains(refStr, "/") {
+		parts := strings.SplitN(refStr, "/", 2)
+		ref.Org = parts[0]
+		refStr = parts[1]
+	}
+	if len(refStr) > 0 {
+		ref.Repo = refStr
+	}
+	return ref
+}
+
+//parse an explicit org/repo pair (without using the defaults of the configuration)
+func parseRepoName(str string) (string, string, error) {
+	parts := strings.Split(str, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" || strings.ContainsAny(str, "@#:") {
+		return "", "", errors.New("Invalid repository (use org/repo): " + str)
+	}
+	return parts[0], parts[1], nil
+}
+
+//set the id (and the kind of the id in case of typed ids)
+func (ref *Reference) setId(id string) {
+	if match := typedIdRE.FindStringSubmatch(id); match != nil {
+		ref.Kind = match[1]
+		ref.Id = match[2]
+		return
+	}
+	ref.Id = id
+}
+
+func parseGithubUrl(ref Reference, str string) Reference {
+	parsed, err := url.Parse(str)
+	if err != nil {
+		return ref
+	}
+	parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(parts) < 2 {
+		return ref
+	}
+	ref.Org = parts[0]
+	ref.Repo = strings.TrimSuffix(parts[1], ".git")
+	rest := parts[2:]
+	if len(rest) >= 2 && (rest[0] == "pull" || rest[0] == "pulls") {
+		ref.Kind = referencePr
+		ref.Id = rest[1]
+	} else if len(rest) >= 3 && rest[0] == "actions" && rest[1] == "runs" {
+		ref.Kind = referenceRun
+		ref.Id = rest[2]
+	} else if len(rest) >= 2 && rest[0] == "tree" {
+		ref.Branch = strings.Join(rest[1:], "/")
+	}
+	return ref
+}
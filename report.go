@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"github.com/pkg/errors"
@@ -13,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type RobotReport struct {
@@ -176,38 +178,52 @@ func readJunitFailingTests(dir string) ([]TestResult, error) {
 	return results, nil
 }
 
+func readJsonFile(file string, target interface{}) error {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	err = json.Unmarshal(content, target)
+	if err != nil {
+		return errors.Wrap(err, "Can't parse "+file)
+	}
+	return nil
+}
+
 func parseBuildResults(root string, buildPath string) (BuildResult, error) {
 	b := BuildResult{}
-	jobs, err := asJson(ioutil.ReadFile(path.Join(root, buildPath, "job.json")))
+	jobs := Jobs{}
+	err := readJsonFile(path.Join(root, buildPath, "job.json"), &jobs)
 	if err != nil {
 		return b, err
 	}
-	run, err := asJson(ioutil.ReadFile(path.Join(root, buildPath, "run.json")))
+	run := WorkflowRun{}
+	err = readJsonFile(path.Join(root, buildPath, "run.json"), &run)
 	if err != nil {
 		return b, err
 	}
 
-	b.Date = ms(run, "created_at")
+	b.Date = run.CreatedAt.Format(time.RFC3339)
 	b.Dir = buildPath
-	b.CommitString = ms(run, "head_commit", "message")
-	b.Conclusion = ms(run, "conclusion")
+	b.CommitString = run.HeadCommit.Message
+	b.Conclusion = run.Conclusion
 	b.TestResults = make(map[string]JobResult)
-	b.ID = mns(run, "run_number")
-	b.Link = ms(run, "html_url")
-	for _, job := range l(m(jobs, "jobs")) {
+	b.ID = strconv.Itoa(run.RunNumber)
+	b.Link = run.HtmlUrl
+	for _, job := range jobs.Jobs {
 
-		failingTests, err := readFailingTests(path.Join(root, buildPath, JobToArtifactName(ms(job, "name"))))
+		failingTests, err := readFailingTests(path.Join(root, buildPath, JobToArtifactName(job.Name)))
 		if err != nil {
 			return b, err
 		}
 		jobResult := JobResult{
-			Name:         ms(job, "name"),
-			Artifact:     JobToArtifactName(ms(job, "name")),
-			Status:       ms(job, "status"),
-			Conclusion:   ms(job, "conclusion"),
+			Name:         job.Name,
+			Artifact:     JobToArtifactName(job.Name),
+			Status:       job.Status,
+			Conclusion:   job.Conclusion,
 			FailingTests: failingTests,
 		}
-		b.TestResults[ms(job, "name")] = jobResult
+		b.TestResults[job.Name] = jobResult
 	}
 	return b, nil
 }
@@ -1,27 +1,227 @@
 package main
 
 import (
-	"errors"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
 )
 
-func rerun(org string, prId string) error {
+type rerunOptions struct {
+	//rerun only the failed (and cancelled) jobs of the failed runs
+	FailedOnly bool
+	//rerun only the job with this name
+	Job string
+	//rerun only the runs of this workflow (id, file name or name)
+	Workflow string
+	//the reference identifies a branch (instead of a pull request or run)
+	Branch bool
+}
+
+//one re-triggered workflow run
+type rerunResult struct {
+	Run WorkflowRun
+	//what is re-triggered (all jobs, failed jobs or the name of a job)
+	Target string
+}
+
+//workflow runs of the referenced run, pull request (last commit) or branch (last commit)
+func runsToRerun(ref Reference, branch bool) ([]WorkflowRun, error) {
+	if ref.Kind == referenceRun {
+		run, err := GetWorkflowRun(ref.Org, ref.Repo, ref.Id)
+		if err != nil {
+			return nil, err
+		}
+		return []WorkflowRun{run}, nil
+	}
+
+	sha := ""
+	if branch {
+		runs, err := readBuilds(Reference{Org: ref.Org, Repo: ref.Repo, Branch: ref.Branch}, "")
+		if err != nil {
+			return nil, err
+		}
+		runs = runsOfRepository(runs)
+		if len(runs) == 0 {
+			return nil, errors.New("Couldn't find any workflow run on the branch " + ref.Branch)
+		}
+		sha = runs[0].HeadSha
+	} else {
+		pr, err := GetPr(ref.Org, ref.Repo, ref.Id)
+		if err != nil {
+			return nil, err
+		}
+		sha = pr.Head.Sha
+	}
+
+	runs, err := GetWorkflowRunsOfSha(ref.Org, ref.Repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	if len(runs.WorkflowRuns) == 0 {
+		return nil, errors.New("Couldn't find any workflow run for the commit " + sha)
+	}
+	return runs.WorkflowRuns, nil
+}
+
+//returns with the runs which are completed with a non-successful conclusion
+func failedRuns(runs []WorkflowRun) []WorkflowRun {
+	result := make([]WorkflowRun, 0)
+	for _, run := range runs {
+		if run.Status != "completed" {
+			continue
+		}
+		switch strings.ToLower(run.Conclusion) {
+		case "success", "neutral", "skipped":
+			continue
+		}
+		result = append(result, run)
+	}
+	return result
+}
+
+//returns with the jobs (of any run) with the name (case insensitive)
+func findJobs(jobs []Job, name string) []Job {
+	result := make([]Job, 0)
+	for _, job := range jobs {
+		if strings.EqualFold(job.Name, name) {
+			result = append(result, job)
+		}
+	}
+	return result
+}
+
+//POST request to a workflow run or job endpoint (rerun, cancel) without response body
+func postRunAction(url string) error {
+	resp, err := callGithubApiV3("POST", url)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+//rerun the workflow runs (or jobs) of a pull request, branch or run
+func rerun(ref Reference, options rerunOptions) error {
+	if options.FailedOnly && options.Job != "" {
+		return errors.New("--failed-only and --job can't be used together")
+	}
+	var err error
+	if !options.Branch {
+		ref, err = resolvePrReference(ref)
+		if err != nil {
+			return err
+		}
+	}
+	//the cached lists can be outdated (previous commit, unfinished runs), runs are selected based on fresh data
+	var runs []WorkflowRun
+	err = withoutCache(func() error {
+		runs, err = runsToRerun(ref, options.Branch)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if options.Workflow != "" {
+		workflowId, err := resolveWorkflowId(ref.Org, ref.Repo, options.Workflow)
+		if err != nil {
+			return err
+		}
+		filtered := make([]WorkflowRun, 0)
+		for _, run := range runs {
+			if strconv.FormatInt(run.WorkflowId, 10) == workflowId {
+				filtered = append(filtered, run)
+			}
+		}
+		runs = filtered
+	}
+
+	results, err := rerunRuns(ref.Org, ref.Repo, runs, options)
+	//runs which are re-triggered before a failure are also reported
+	for _, result := range results {
+		fmt.Printf("%s #%d (%s) is re-triggered: %s\n", result.Run.Name, result.Run.RunNumber, result.Target, rerunUrl(ref.Org, ref.Repo, result.Run))
+	}
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return errors.New("No workflow run is re-triggered")
+	}
+	return nil
+}
 
-	commits, err := GetPrCommits(org, "hadoop-ozone", prId)
+//POST a rerun request and remove the outdated cache entries of the run (the new attempt has the same run id)
+func postRerun(org string, repo string, run WorkflowRun, url string) error {
+	err := postRunAction(url)
 	if err != nil {
 		return err
 	}
-	lastCommit := ms(commits[len(commits)-1], "sha")
+	return invalidateWorkflowRun(org, repo, run)
+}
 
-	workflowRuns, err := GetWorkflowRuns(org, "hadoop-ozone", "4453")
-	for _, workflowRun := range l(m(workflowRuns, "workflow_runs")) {
-		if ms(workflowRun, "head_sha") == lastCommit {
-			_, err := callGithubApiV3("POST", ms(workflowRun, "rerun_url"))
+func rerunRuns(org string, repo string, runs []WorkflowRun, options rerunOptions) ([]rerunResult, error) {
+	results := make([]rerunResult, 0)
+	if options.Job != "" {
+		available := make([]string, 0)
+		for _, run := range runs {
+			jobs, err := GetWorkflowRunJobs(org, repo, run.IdString())
 			if err != nil {
-				return err
+				return results, err
+			}
+			for _, job := range findJobs(jobs.Jobs, options.Job) {
+				err = postRerun(org, repo, run, apiUrl("/repos/")+org+"/"+repo+"/actions/jobs/"+strconv.FormatInt(job.Id, 10)+"/rerun")
+				if err != nil {
+					return results, err
+				}
+				results = append(results, rerunResult{Run: run, Target: job.Name})
+			}
+			for _, job := range jobs.Jobs {
+				available = append(available, job.Name)
 			}
-			return nil
+		}
+		if len(results) == 0 {
+			return nil, errors.New("Job " + options.Job + " is not found. Available jobs: " + strings.Join(available, ", "))
+		}
+		return results, nil
+	}
+
+	if options.FailedOnly {
+		runs = failedRuns(runs)
+		if len(runs) == 0 {
+			return nil, errors.New("None of the workflow runs are failed")
 		}
 	}
-	return errors.New("Couldn't find recent workflow run with the SHA of the last commit in the PR " + lastCommit)
+	for _, run := range runs {
+		if run.Status != "completed" {
+			fmt.Printf("%s #%d is skipped (%s)\n", run.Name, run.RunNumber, run.Status)
+			continue
+		}
+		url := apiUrl("/repos/") + org + "/" + repo + "/actions/runs/" + run.IdString()
+		target := "all jobs"
+		if options.FailedOnly {
+			url += "/rerun-failed-jobs"
+			target = "failed jobs"
+		} else {
+			url += "/rerun"
+		}
+		err := postRerun(org, repo, run, url)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, rerunResult{Run: run, Target: target})
+	}
+	return results, nil
+}
 
+//url of the new attempt of the re-triggered run
+func rerunUrl(org string, repo string, run WorkflowRun) string {
+	body, err := readGithubApiV3(apiUrl("/repos/") + org + "/" + repo + "/actions/runs/" + run.IdString())
+	if err != nil {
+		return run.HtmlUrl
+	}
+	current := WorkflowRun{}
+	if err = json.Unmarshal(body, &current); err != nil || current.RunAttempt == 0 {
+		return run.HtmlUrl
+	}
+	return current.HtmlUrl + "/attempts/" + strconv.Itoa(current.RunAttempt)
 }
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRerunFailedOnly(t *testing.T) {
+	posts := make([]string, 0)
+	jobRequests := 0
+	cleanup := withFakeGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			posts = append(posts, r.URL.Path)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		switch r.URL.Path {
+		case "/repos/apache/ozone/pulls/123":
+			_, _ = w.Write([]byte(`{"number": 123, "head": {"sha": "abcd"}}`))
+		case "/repos/apache/ozone/actions/runs":
+			assert.Equal(t, "abcd", r.URL.Query().Get("head_sha"))
+			_, _ = w.Write([]byte(`{"total_count": 3, "workflow_runs": [
+				{"id": 1, "name": "build-branch", "status": "completed", "conclusion": "failure"},
+				{"id": 2, "name": "codeql", "status": "completed", "conclusion": "success"},
+				{"id": 3, "name": "label", "status": "in_progress"}]}`))
+		case "/repos/apache/ozone/actions/runs/1/jobs":
+			jobRequests++
+			_, _ = w.Write([]byte(`{"total_count": 1, "jobs": [{"id": 10, "name": "unit", "status": "completed", "conclusion": "failure"}]}`))
+		case "/repos/apache/ozone/actions/runs/1":
+			_, _ = w.Write([]byte(`{"id": 1, "run_attempt": 2, "html_url": "https://github.com/apache/ozone/actions/runs/1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer cleanup()
+
+	_, err := GetWorkflowRunJobs("apache", "ozone", "1")
+	assert.Nil(t, err)
+
+	ref := Reference{Org: "apache", Repo: "ozone", Id: "123", Kind: referencePr}
+	err = rerun(ref, rerunOptions{FailedOnly: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/repos/apache/ozone/actions/runs/1/rerun-failed-jobs"}, posts)
+
+	//the completed jobs of the previous attempt are not served from the cache
+	_, err = GetWorkflowRunJobs("apache", "ozone", "1")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, jobRequests)
+
+	assert.Equal(t, "https://github.com/apache/ozone/actions/runs/1/attempts/2", rerunUrl("apache", "ozone", WorkflowRun{Id: 1}))
+}
+
+func TestRerunBranch(t *testing.T) {
+	posts := make([]string, 0)
+	latestSha := "old"
+	cleanup := withFakeGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			posts = append(posts, r.URL.Path)
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		switch r.URL.Query().Get("head_sha") {
+		case "":
+			//the latest run of the branch is pushed to a fork
+			_, _ = w.Write([]byte(`{"total_count": 2, "workflow_runs": [
+				{"id": 3, "head_sha": "fork", "status": "completed", "conclusion": "failure", "repository": {"full_name": "apache/ozone"}, "head_repository": {"full_name": "elek/ozone"}},
+				{"id": 2, "head_sha": "` + latestSha + `", "status": "completed", "conclusion": "failure", "repository": {"full_name": "apache/ozone"}, "head_repository": {"full_name": "apache/ozone"}}]}`))
+		case "new":
+			_, _ = w.Write([]byte(`{"total_count": 1, "workflow_runs": [{"id": 2, "head_sha": "new", "status": "completed", "conclusion": "failure"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"total_count": 0, "workflow_runs": []}`))
+		}
+	})
+	defer cleanup()
+
+	ref := Reference{Org: "apache", Repo: "ozone", Branch: "master"}
+	_, err := readBuilds(ref, "")
+	assert.Nil(t, err)
+
+	//new commit is pushed after the list is cached
+	latestSha = "new"
+	err = rerun(ref, rerunOptions{Branch: true})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/repos/apache/ozone/actions/runs/2/rerun"}, posts)
+}
+
+func TestFindJobs(t *testing.T) {
+	jobs := []Job{{Id: 10, Name: "unit"}, {Id: 11, Name: "acceptance (secure)"}}
+
+	assert.Equal(t, []Job{{Id: 11, Name: "acceptance (secure)"}}, findJobs(jobs, "Acceptance (secure)"))
+	assert.Empty(t, findJobs(jobs, "acceptance"))
+}
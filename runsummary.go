@@ -0,0 +1,105 @@
+package main
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//machine readable representation of one workflow run (used by the json/csv/tsv outputs)
+type runSummary struct {
+	Id         int64        `json:"id"`
+	RunNumber  int          `json:"runNumber"`
+	Workflow   string       `json:"workflow"`
+	Event      string       `json:"event"`
+	Branch     string       `json:"branch"`
+	Sha        string       `json:"sha"`
+	Commit     string       `json:"commit"`
+	Status     string       `json:"status"`
+	Conclusion string       `json:"conclusion"`
+	CreatedAt  time.Time    `json:"createdAt"`
+	Url        string       `json:"url"`
+	Jobs       []jobSummary `json:"jobs"`
+}
+
+type jobSummary struct {
+	Name       string `json:"name"`
+	Group      string `json:"group"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+	//duration of the finished jobs (0 for the pending ones)
+	DurationSeconds int64 `json:"durationSeconds"`
+}
+
+func summarizeJob(job Job) jobSummary {
+	summary := jobSummary{
+		Name:       job.Name,
+		Group:      jobGroups[jobGroupIndex(job.Name)],
+		Status:     job.Status,
+		Conclusion: job.Conclusion,
+	}
+	if !job.StartedAt.IsZero() && !job.CompletedAt.IsZero() {
+		summary.DurationSeconds = int64(job.CompletedAt.Sub(job.StartedAt).Seconds())
+	}
+	return summary
+}
+
+func summarizeRun(run WorkflowRun, workflow Workflow, jobs []Job) runSummary {
+	summary := runSummary{
+		Id:         run.Id,
+		RunNumber:  run.RunNumber,
+		Workflow:   workflow.Name,
+		Event:      run.Event,
+		Branch:     run.HeadBranch,
+		Sha:        run.HeadSha,
+		Commit:     strings.Split(run.HeadCommit.Message, "\n")[0],
+		Status:     run.Status,
+		Conclusion: run.Conclusion,
+		CreatedAt:  run.CreatedAt,
+		Url:        run.HtmlUrl,
+		Jobs:       make([]jobSummary, 0),
+	}
+	for _, job := range jobs {
+		summary.Jobs = append(summary.Jobs, summarizeJob(job))
+	}
+	return summary
+}
+
+//print the runs as json, or one csv/tsv record per job (runs without jobs are printed with empty job fields)
+func printRunSummaries(out io.Writer, format string, runs []runSummary) error {
+	if format == outputJson {
+		return writeJson(out, runs)
+	}
+
+	header := []string{"run_id", "run_number", "workflow", "event", "branch", "sha", "commit", "run_status",
+		"run_conclusion", "created", "job", "group", "status", "conclusion", "duration_seconds"}
+	rows := make([][]string, 0)
+	for _, run := range runs {
+		runFields := []string{
+			strconv.FormatInt(run.Id, 10),
+			strconv.Itoa(run.RunNumber),
+			run.Workflow,
+			run.Event,
+			run.Branch,
+			run.Sha,
+			run.Commit,
+			run.Status,
+			run.Conclusion,
+			run.CreatedAt.Format(time.RFC3339),
+		}
+		if len(run.Jobs) == 0 {
+			rows = append(rows, append(runFields, "", "", "", "", ""))
+		}
+		for _, job := range run.Jobs {
+			row := append([]string{}, runFields...)
+			rows = append(rows, append(row,
+				job.Name,
+				job.Group,
+				job.Status,
+				job.Conclusion,
+				strconv.FormatInt(job.DurationSeconds, 10)))
+		}
+	}
+	return writeRecords(out, format, header, rows)
+}
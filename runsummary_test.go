@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeRun(t *testing.T) {
+	started, _ := time.Parse(time.RFC3339, "2020-07-01T10:00:00Z")
+	run := WorkflowRun{Id: 123, RunNumber: 7, HeadBranch: "master", HeadCommit: HeadCommit{Message: "HDDS-1. First\n\nDetails"}}
+	jobs := []Job{
+		{Name: "unit", Status: "completed", Conclusion: "success", StartedAt: started, CompletedAt: started.Add(90 * time.Second)},
+		{Name: "it (ozone)", Status: "in_progress", StartedAt: started},
+		{Name: "acceptance (secure)", Status: "completed", Conclusion: "failure", StartedAt: started, CompletedAt: started.Add(time.Hour)},
+		{Name: "kubernetes", Status: "queued"},
+	}
+
+	summary := summarizeRun(run, Workflow{Name: "build-branch"}, jobs)
+
+	assert.Equal(t, "HDDS-1. First", summary.Commit)
+	assert.Equal(t, "build-branch", summary.Workflow)
+	assert.Len(t, summary.Jobs, 4)
+	assert.Equal(t, jobSummary{Name: "unit", Group: "basic", Status: "completed", Conclusion: "success", DurationSeconds: 90}, summary.Jobs[0])
+	assert.Equal(t, int64(0), summary.Jobs[1].DurationSeconds)
+	assert.Equal(t, "acceptance", summary.Jobs[2].Group)
+	assert.Equal(t, "other", summary.Jobs[3].Group)
+}
+
+func TestPrintRunSummariesCsv(t *testing.T) {
+	runs := []runSummary{
+		summarizeRun(WorkflowRun{Id: 1}, Workflow{Name: "build"}, []Job{{Name: "unit"}, {Name: "integration (ozone)"}}),
+		summarizeRun(WorkflowRun{Id: 2}, Workflow{Name: "build"}, []Job{}),
+	}
+	out := bytes.Buffer{}
+	assert.Nil(t, printRunSummaries(&out, outputCsv, runs))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "run_id,"))
+	assert.True(t, strings.HasPrefix(lines[2], "1,"))
+	assert.Contains(t, lines[2], "integration (ozone),integration")
+	assert.True(t, strings.HasPrefix(lines[3], "2,"))
+}
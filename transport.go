@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//timeout of one Github API request attempt (including reading the response body), waits between the retries are not included
+var requestTimeout = 60 * time.Second
+
+//http transport which retries failed requests and handles the Github rate limits
+type retryTransport struct {
+	base http.RoundTripper
+	//number of retries in case of network errors and 5xx responses
+	maxRetries int
+	//wait time before the first retry, doubled after each attempt
+	backoff time.Duration
+	//maximum time to sleep until a rate limit is reset, fail if the wait would be longer
+	maxRateLimitWait time.Duration
+	//timeout of one attempt (zero means no timeout)
+	attemptTimeout time.Duration
+	//wait before the next attempt, returns with error if the request is cancelled in the meantime
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(base http.RoundTripper) *retryTransport {
+	return &retryTransport{
+		base:             base,
+		maxRetries:       3,
+		backoff:          time.Second,
+		maxRateLimitWait: 5 * time.Minute,
+		sleep:            sleepContext,
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+//response body which releases the context of the attempt when it's closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (body cancelOnClose) Close() error {
+	err := body.ReadCloser.Close()
+	body.cancel()
+	return err
+}
+
+//shared transport of all the Github API calls
+var githubTransport = newRetryTransport(http.DefaultTransport)
+
+//client for API calls where the full response is read. The timeout is applied per attempt, rate limit waits don't count
+func githubClient() *http.Client {
+	transport := *githubTransport
+	transport.attemptTimeout = requestTimeout
+	return &http.Client{
+		Transport: &transport,
+	}
+}
+
+//client for streaming (potentially huge) responses, like artifact downloads
+func githubStreamClient() *http.Client {
+	return &http.Client{
+		Transport: githubTransport,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if offline {
+		return nil, fmt.Errorf("%s can't be requested in offline mode", req.URL)
+	}
+	backoff := t.backoff
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, fmt.Errorf("request body of %s can't be replayed for retry", req.URL)
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+		cancel := context.CancelFunc(func() {})
+		if t.attemptTimeout > 0 {
+			var ctx context.Context
+			ctx, cancel = context.WithTimeout(req.Context(), t.attemptTimeout)
+			attemptReq = attemptReq.WithContext(ctx)
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		retriesLeft := attempt < t.maxRetries && req.Context().Err() == nil && retryable(req)
+
+		if err != nil {
+			cancel()
+			if !retriesLeft {
+				return nil, err
+			}
+			log.Warn().Msgf("Request to %s is failed (%s), retrying in %s", req.URL, err.Error(), backoff)
+		} else if wait, limited := rateLimitWait(resp, time.Now()); limited {
+			discard(resp)
+			cancel()
+			if wait > t.maxRateLimitWait || !retriesLeft || !beforeDeadline(req.Context(), wait) {
+				return nil, fmt.Errorf("Github API rate limit is exceeded (%s), it will be reset in %s", resp.Status, wait.Round(time.Second))
+			}
+			log.Warn().Msgf("Github API rate limit is exceeded, waiting %s", wait.Round(time.Second))
+			if err := t.sleep(req.Context(), wait); err != nil {
+				return nil, err
+			}
+			continue
+		} else if resp.StatusCode >= 500 && retriesLeft {
+			log.Warn().Msgf("Request to %s is failed (%s), retrying in %s", req.URL, resp.Status, backoff)
+			discard(resp)
+			cancel()
+		} else {
+			resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+			return resp, nil
+		}
+		if err := t.sleep(req.Context(), backoff); err != nil {
+			return nil, err
+		}
+		backoff *= 2
+	}
+}
+
+//true if the request can wait the given time without exceeding its deadline
+func beforeDeadline(ctx context.Context, wait time.Duration) bool {
+	deadline, hasDeadline := ctx.Deadline()
+	return !hasDeadline || time.Now().Add(wait).Before(deadline)
+}
+
+//failed requests can be retried only if they don't change anything (graphql calls of ogh are read-only queries)
+func retryable(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead || strings.HasSuffix(req.URL.Path, "/graphql")
+}
+
+//returns the time to wait if the response is rejected by a (primary or secondary) rate limit
+func rateLimitWait(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		seconds, err := strconv.Atoi(retryAfter)
+		if err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err != nil {
+			return time.Minute, true
+		}
+		wait := time.Unix(reset, 0).Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func discard(resp *http.Response) {
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
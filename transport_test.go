@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testTransport() (*retryTransport, *[]time.Duration) {
+	sleeps := make([]time.Duration, 0)
+	transport := newRetryTransport(http.DefaultTransport)
+	transport.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+	return transport, &sleeps
+}
+
+func TestRetryTransportRetries5xx(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	transport, sleeps := testTransport()
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *sleeps)
+}
+
+func TestRetryTransportGivesUp(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	transport, _ := testTransport()
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, 500, resp.StatusCode)
+	assert.Equal(t, 4, calls)
+
+	calls = 0
+	resp, err = (&http.Client{Transport: transport}).Post(server.URL, "application/json", strings.NewReader("{}"))
+	assert.Nil(t, err)
+	assert.Equal(t, 500, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryTransportSecondaryRateLimit(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	transport, sleeps := testTransport()
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, []time.Duration{30 * time.Second}, *sleeps)
+}
+
+func TestRetryTransportRateLimitTooLong(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	transport, sleeps := testTransport()
+	_, err := (&http.Client{Transport: transport}).Get(server.URL)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "rate limit is exceeded")
+	assert.Empty(t, *sleeps)
+}
+
+func TestRetryTransportRateLimitWaitIsCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	transport := newRetryTransport(http.DefaultTransport)
+	req, err := http.NewRequest("GET", server.URL, nil)
+	assert.Nil(t, err)
+
+	//the wait wouldn't fit into the deadline of the request
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err = (&http.Client{Transport: transport}).Do(req.WithContext(ctx))
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "rate limit is exceeded")
+
+	//the sleep returns as soon as the request is cancelled
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, sleepContext(ctx, time.Hour))
+}
+
+func TestRetryTransportAttemptTimeout(t *testing.T) {
+	//the handler of the timed out attempt is still running during the retry
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	transport, _ := testTransport()
+	transport.attemptTimeout = 50 * time.Millisecond
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	assert.Nil(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Body.Close())
+	assert.Equal(t, "ok", string(body))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"fmt"
+	"github.com/fatih/color"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//move the cursor to the top-left corner and clear the terminal
+const clearScreen = "\033[H\033[2J"
+
+//flags of the build listing commands (output format and watch mode)
+func buildListFlags() []cli.Flag {
+	return []cli.Flag{
+		cli.BoolFlag{
+			Name:  "watch, w",
+			Usage: "Refresh the table until the running builds are finished (exit code is non-zero if any of them failed)",
+		},
+		cli.DurationFlag{
+			Name:  "interval",
+			Usage: "Refresh interval of the watch mode (the run list is cached for 3 minutes, shorter intervals may show cached data)",
+			Value: 3 * time.Minute,
+		},
+		outputFlag(),
+	}
+}
+
+func buildListOptionsFromContext(c *cli.Context) (buildListOptions, error) {
+	options := buildListOptions{
+		Watch:    c.Bool("watch"),
+		Interval: c.Duration("interval"),
+	}
+	var err error
+	options.Format, err = outputFormat(c)
+	if err != nil {
+		return options, err
+	}
+	if options.Watch && options.Format != outputTable {
+		return options, errors.New("Watch mode supports only the table output")
+	}
+	if options.Interval <= 0 {
+		return options, errors.New("Watch interval should be positive")
+	}
+	return options, nil
+}
+
+//state of a watch session: the watched runs and the last seen status of the jobs
+type buildWatcher struct {
+	watched  map[int64]bool
+	seen     map[int64]bool
+	statuses map[string]string
+}
+
+func newBuildWatcher() *buildWatcher {
+	return &buildWatcher{
+		watched:  make(map[int64]bool),
+		seen:     make(map[int64]bool),
+		statuses: make(map[string]string),
+	}
+}
+
+func jobKey(run WorkflowRun, job Job) string {
+	return run.IdString() + "/" + job.Name
+}
+
+func runCompleted(detail runDetails) bool {
+	return strings.ToLower(detail.Run.Status) == "completed" && Jobs{Jobs: detail.Jobs}.Completed()
+}
+
+func runFailed(detail runDetails) bool {
+	conclusion := strings.ToLower(detail.Run.Conclusion)
+	return summarizeChecks(detail.Jobs).Failed > 0 || conclusion == "failure" || conclusion == "timed_out"
+}
+
+//register the current state of the runs and return with the keys of the jobs where the status is changed.
+//Watched runs are the unfinished runs of the first poll (or the newest one if all of them are finished) and the runs which are started later.
+func (w *buildWatcher) observe(details []runDetails) map[string]bool {
+	first := len(w.seen) == 0
+	for _, detail := range details {
+		if w.seen[detail.Run.Id] {
+			continue
+		}
+		w.seen[detail.Run.Id] = true
+		if !first || !runCompleted(detail) {
+			w.watched[detail.Run.Id] = true
+		}
+	}
+	if first && len(w.watched) == 0 && len(details) > 0 {
+		w.watched[details[0].Run.Id] = true
+	}
+
+	changed := make(map[string]bool)
+	for _, detail := range details {
+		for _, job := range detail.Jobs {
+			key := jobKey(detail.Run, job)
+			statusChr := jobStatusChr(job)
+			if previous, found := w.statuses[key]; (found && previous != statusChr) || (!found && !first) {
+				changed[key] = true
+			}
+			w.statuses[key] = statusChr
+		}
+	}
+	return changed
+}
+
+//ids of the watched runs which are not included in the details (eg. pushed out from the list by newer runs)
+func (w *buildWatcher) missing(details []runDetails) []string {
+	included := make(map[int64]bool)
+	for _, detail := range details {
+		included[detail.Run.Id] = true
+	}
+	ids := make([]string, 0)
+	for id := range w.watched {
+		if !included[id] {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+//returns true if all the watched runs are finished (and the description of the failed runs).
+//Watched runs which are not included in the details are considered as unfinished.
+func (w *buildWatcher) finished(details []runDetails) (bool, []string) {
+	if len(w.missing(details)) > 0 {
+		return false, nil
+	}
+	failed := make([]string, 0)
+	for _, detail := range details {
+		if !w.watched[detail.Run.Id] {
+			continue
+		}
+		if !runCompleted(detail) {
+			return false, nil
+		}
+		if runFailed(detail) {
+			failed = append(failed, fmt.Sprintf("#%d (%s)", detail.Run.RunNumber, detail.Run.HeadBranch))
+		}
+	}
+	return true, failed
+}
+
+//details of the watched runs which are not included in the listed runs
+func readMissingRuns(org string, repo string, watcher *buildWatcher, details []runDetails) ([]runDetails, error) {
+	runs := make([]WorkflowRun, 0)
+	for _, id := range watcher.missing(details) {
+		run, err := GetWorkflowRun(org, repo, id)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return readRunDetails(org, repo, runs)
+}
+
+//read the listed runs and the watched runs which are not listed any more
+func pollWatchedRuns(org string, repo string, read func() ([]WorkflowRun, error), watcher *buildWatcher) ([]runDetails, []runDetails, error) {
+	runs, err := read()
+	if err != nil {
+		return nil, nil, err
+	}
+	details, err := readRunDetails(org, repo, runs)
+	if err != nil {
+		return nil, nil, err
+	}
+	missing, err := readMissingRuns(org, repo, watcher, details)
+	return details, missing, err
+}
+
+//poll the runs and redraw the table until the watched runs are finished
+func watchWorkflowRuns(org string, repo string, read func() ([]WorkflowRun, error), interval time.Duration) error {
+	return watchRuns(os.Stdout, org, repo, read, interval)
+}
+
+func watchRuns(out io.Writer, org string, repo string, read func() ([]WorkflowRun, error), interval time.Duration) error {
+	watcher := newBuildWatcher()
+	for {
+		details, missing, err := pollWatchedRuns(org, repo, read, watcher)
+		if err != nil {
+			//without the first successful poll there is nothing to watch
+			if len(watcher.seen) == 0 {
+				return err
+			}
+			fmt.Fprintf(out, "Refresh is failed at %s (%s), retrying in %s\n", time.Now().Format("15:04:05"), err.Error(), interval)
+			time.Sleep(interval)
+			continue
+		}
+		changed := watcher.observe(details)
+
+		fmt.Fprint(out, clearScreen)
+		renderRunTable(out, details, func(detail runDetails) string {
+			return formatSteps(detail.Jobs, func(job Job, statusChr string) string {
+				if changed[jobKey(detail.Run, job)] {
+					return color.New(color.FgYellow, color.Bold).Sprint(statusChr)
+				}
+				return statusChr
+			})
+		})
+
+		done, failed := watcher.finished(append(details, missing...))
+		if done {
+			if len(failed) > 0 {
+				//expected result, reported with exit code 1 (without stack trace)
+				return cli.NewExitError("Watched runs are finished with failures: "+strings.Join(failed, ", "), 1)
+			}
+			fmt.Fprintln(out, "Watched runs are finished successfully")
+			return nil
+		}
+		fmt.Fprintf(out, "Updated at %s, refreshing in every %s (Ctrl-C to stop)\n", time.Now().Format("15:04:05"), interval)
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+func TestBuildWatcher(t *testing.T) {
+	finished := runDetails{
+		Run:  WorkflowRun{Id: 1, RunNumber: 1, Status: "completed", Conclusion: "success"},
+		Jobs: []Job{{Name: "unit", Status: "completed", Conclusion: "success"}},
+	}
+	running := runDetails{
+		Run: WorkflowRun{Id: 2, RunNumber: 2, HeadBranch: "HDDS-1", Status: "in_progress"},
+		Jobs: []Job{
+			{Name: "unit", Status: "completed", Conclusion: "success"},
+			{Name: "integration (ozone)", Status: "in_progress"},
+		},
+	}
+	watcher := newBuildWatcher()
+
+	changed := watcher.observe([]runDetails{running, finished})
+	assert.Empty(t, changed)
+	assert.Equal(t, map[int64]bool{2: true}, watcher.watched)
+	done, _ := watcher.finished([]runDetails{running, finished})
+	assert.False(t, done)
+
+	running.Run.Status = "completed"
+	running.Run.Conclusion = "failure"
+	running.Jobs = []Job{
+		{Name: "unit", Status: "completed", Conclusion: "success"},
+		{Name: "integration (ozone)", Status: "completed", Conclusion: "failure"},
+	}
+	changed = watcher.observe([]runDetails{running, finished})
+	assert.Equal(t, map[string]bool{"2/integration (ozone)": true}, changed)
+	done, failed := watcher.finished([]runDetails{running, finished})
+	assert.True(t, done)
+	assert.Equal(t, []string{"#2 (HDDS-1)"}, failed)
+}
+
+func TestBuildWatcherWithoutRunningBuild(t *testing.T) {
+	newest := runDetails{Run: WorkflowRun{Id: 3, Status: "completed", Conclusion: "success"}}
+	older := runDetails{Run: WorkflowRun{Id: 1, Status: "completed", Conclusion: "failure"}}
+	watcher := newBuildWatcher()
+
+	watcher.observe([]runDetails{newest, older})
+
+	done, failed := watcher.finished([]runDetails{newest, older})
+	assert.True(t, done)
+	assert.Empty(t, failed)
+}
+
+func TestBuildWatcherMissingRun(t *testing.T) {
+	running := runDetails{Run: WorkflowRun{Id: 2, RunNumber: 2, Status: "in_progress"}}
+	newer := runDetails{Run: WorkflowRun{Id: 3, RunNumber: 3, Status: "completed", Conclusion: "success"}}
+	watcher := newBuildWatcher()
+	watcher.observe([]runDetails{running})
+
+	//the watched run is pushed out from the list by the newer runs
+	watcher.observe([]runDetails{newer})
+	assert.Equal(t, []string{"2"}, watcher.missing([]runDetails{newer}))
+	done, _ := watcher.finished([]runDetails{newer})
+	assert.False(t, done)
+
+	running.Run.Status = "completed"
+	running.Run.Conclusion = "success"
+	done, failed := watcher.finished([]runDetails{newer, running})
+	assert.True(t, done)
+	assert.Empty(t, failed)
+}
+
+func TestWatchRunsRetriesAfterError(t *testing.T) {
+	cleanup := withFakeGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/apache/ozone/actions/runs/1/jobs":
+			_, _ = w.Write([]byte(`{"total_count": 0, "jobs": []}`))
+		case "/repos/apache/ozone/actions/workflows/10":
+			_, _ = w.Write([]byte(`{"id": 10, "name": "build-branch"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer cleanup()
+
+	polls := 0
+	read := func() ([]WorkflowRun, error) {
+		polls++
+		switch polls {
+		case 1:
+			return []WorkflowRun{{Id: 1, WorkflowId: 10, Status: "in_progress"}}, nil
+		case 2:
+			return nil, errors.New("connection reset")
+		default:
+			return []WorkflowRun{{Id: 1, WorkflowId: 10, Status: "completed", Conclusion: "success"}}, nil
+		}
+	}
+	out := &bytes.Buffer{}
+	err := watchRuns(out, "apache", "ozone", read, time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, polls)
+	assert.Contains(t, out.String(), "connection reset")
+	assert.Contains(t, out.String(), "Watched runs are finished successfully")
+}
+
+func TestWatchRunsFailure(t *testing.T) {
+	cleanup := withFakeGithub(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/apache/ozone/actions/runs/1/jobs":
+			_, _ = w.Write([]byte(`{"total_count": 0, "jobs": []}`))
+		case "/repos/apache/ozone/actions/workflows/10":
+			_, _ = w.Write([]byte(`{"id": 10, "name": "build-branch"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer cleanup()
+
+	read := func() ([]WorkflowRun, error) {
+		return []WorkflowRun{{Id: 1, WorkflowId: 10, Name: "build-branch", Status: "completed", Conclusion: "failure"}}, nil
+	}
+	err := watchRuns(&bytes.Buffer{}, "apache", "ozone", read, time.Millisecond)
+	assert.NotNil(t, err)
+	exitErr, ok := err.(cli.ExitCoder)
+	assert.True(t, ok)
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.Contains(t, err.Error(), "finished with failures")
+}